@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+)
+
+const maxCoverLetterLength = 5000
+
+// ErrAlreadyApplied возвращается при повторном отклике на ту же вакансию
+var ErrAlreadyApplied = errors.New("you have already applied to this vacancy")
+
+// InvalidStatusTransitionError возвращается при недопустимой смене этапа отклика
+type InvalidStatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change application status from '%s' to '%s'", e.From, e.To)
+}
+
+// applicationStatusTitles названия этапов отклика для уведомлений студенту
+var applicationStatusTitles = map[string]string{
+	entities.ApplicationStatusNew:         "новый",
+	entities.ApplicationStatusViewed:      "просмотрен",
+	entities.ApplicationStatusShortlisted: "в списке кандидатов",
+	entities.ApplicationStatusInterview:   "собеседование",
+	entities.ApplicationStatusOffer:       "предложение о работе",
+	entities.ApplicationStatusHired:       "принят на работу",
+	entities.ApplicationStatusRejected:    "отказ",
+}
+
+type ApplicationService struct {
+	repo        repositories.ApplicationRepository
+	vacancyRepo repositories.VacancyRepository
+	publisher   services.NotificationPublisher
+	access      vacancyAccess
+}
+
+func NewApplicationService(repo repositories.ApplicationRepository, vacancyRepo repositories.VacancyRepository,
+	companyRepo repositories.CompanyRepository, publisher services.NotificationPublisher) *ApplicationService {
+	return &ApplicationService{
+		repo:        repo,
+		vacancyRepo: vacancyRepo,
+		publisher:   publisher,
+		access:      vacancyAccess{companyRepo: companyRepo},
+	}
+}
+
+// Apply создает отклик студента на вакансию
+func (s *ApplicationService) Apply(ctx context.Context, studentID, vacancyID, coverLetter string) (*entities.Application, error) {
+	if studentID == "" {
+		return nil, errors.New("student_id is required")
+	}
+	if len([]rune(coverLetter)) > maxCoverLetterLength {
+		return nil, errors.New("cover_letter is too long")
+	}
+
+	vacancy, err := s.vacancyRepo.FindByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vacancy == nil {
+		return nil, errors.New("vacancy not found")
+	}
+
+	// Откликнуться можно только на активную вакансию с непрошедшим дедлайном
+	if vacancy.Status != entities.VacancyStatusActive {
+		return nil, errors.New("vacancy is not accepting applications")
+	}
+	if !vacancy.Deadline.IsZero() && time.Now().After(vacancy.Deadline) {
+		return nil, errors.New("vacancy deadline has passed")
+	}
+
+	existing, err := s.repo.FindByVacancyAndStudent(ctx, vacancyID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyApplied
+	}
+
+	application := &entities.Application{
+		VacancyID:   vacancyID,
+		StudentID:   studentID,
+		CoverLetter: coverLetter,
+		Status:      entities.ApplicationStatusNew,
+		History: []entities.ApplicationStatusChange{
+			{
+				To:        entities.ApplicationStatusNew,
+				ChangedBy: studentID,
+				ChangedAt: time.Now(),
+			},
+		},
+	}
+
+	// Уникальный индекс в хранилище отсекает повторный отклик при параллельных запросах,
+	// поэтому счетчик увеличивается только после успешной вставки
+	if err := s.repo.Create(ctx, application); err != nil {
+		if errors.Is(err, repositories.ErrApplicationExists) {
+			return nil, ErrAlreadyApplied
+		}
+		return nil, err
+	}
+
+	if err := s.vacancyRepo.IncrementResponses(ctx, vacancyID); err != nil {
+		log.Printf("failed to increment responses for vacancy %s: %v", vacancyID, err)
+	}
+	s.notifyApplicationCreated(ctx, vacancy, application)
+
+	return application, nil
+}
+
+// ChangeStatus переводит отклик на следующий этап; доступно автору вакансии или команде ее компании
+func (s *ApplicationService) ChangeStatus(ctx context.Context, employerID, applicationID, status string) (*entities.Application, error) {
+	if !entities.IsValidApplicationStatus(status) {
+		return nil, errors.New("invalid application status")
+	}
+
+	application, err := s.repo.FindByID(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if application == nil {
+		return nil, errors.New("application not found")
+	}
+
+	vacancy, err := s.vacancyRepo.FindByID(ctx, application.VacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vacancy == nil {
+		return nil, ErrNotVacancyOwner
+	}
+	if err := s.access.check(ctx, employerID, vacancy, entities.CompanyPermManageApplications); err != nil {
+		return nil, err
+	}
+
+	current := application.Status
+	if !entities.CanTransitionApplication(current, status) {
+		return nil, &InvalidStatusTransitionError{From: current, To: status}
+	}
+
+	change := entities.ApplicationStatusChange{
+		From:      current,
+		To:        status,
+		ChangedBy: employerID,
+		ChangedAt: time.Now(),
+	}
+	if err := s.repo.UpdateStatus(ctx, application.ID, application.Status, change); err != nil {
+		return nil, err
+	}
+
+	application.Status = status
+	application.UpdatedAt = change.ChangedAt
+	application.History = append(application.History, change)
+
+	publishNotifications(ctx, s.publisher, &entities.Notification{
+		UserID: application.StudentID,
+		Type:   entities.NotificationTypeApplicationStatusChanged,
+		Title:  fmt.Sprintf("Отклик на вакансию «%s»: %s", vacancy.Title, applicationStatusTitle(status)),
+		Body:   fmt.Sprintf("Работодатель перевел ваш отклик на этап «%s».", applicationStatusTitle(status)),
+		Data: map[string]interface{}{
+			"application_id": application.ID,
+			"vacancy_id":     vacancy.ID,
+			"from":           current,
+			"to":             status,
+		},
+	})
+	return application, nil
+}
+
+// GetVacancyApplications получает отклики на вакансию; доступно автору вакансии или команде ее компании
+func (s *ApplicationService) GetVacancyApplications(ctx context.Context, employerID, vacancyID string) ([]*entities.Application, error) {
+	vacancy, err := s.vacancyRepo.FindByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vacancy == nil {
+		return nil, errors.New("vacancy not found")
+	}
+	if err := s.access.check(ctx, employerID, vacancy, entities.CompanyPermViewApplications); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindByVacancy(ctx, vacancyID)
+}
+
+// GetStudentApplications получает все отклики студента
+func (s *ApplicationService) GetStudentApplications(ctx context.Context, studentID string) ([]*entities.Application, error) {
+	return s.repo.FindByStudent(ctx, studentID)
+}
+
+// notifyApplicationCreated уведомляет о новом отклике всех, кто может просматривать отклики на вакансию
+func (s *ApplicationService) notifyApplicationCreated(ctx context.Context, vacancy *entities.Vacancy, application *entities.Application) {
+	recipients, err := s.access.recipients(ctx, vacancy, entities.CompanyPermViewApplications)
+	if err != nil {
+		log.Printf("failed to resolve recipients for vacancy %s: %v", vacancy.ID, err)
+		return
+	}
+
+	notifications := make([]*entities.Notification, len(recipients))
+	for i, userID := range recipients {
+		notifications[i] = &entities.Notification{
+			UserID: userID,
+			Type:   entities.NotificationTypeApplicationCreated,
+			Title:  fmt.Sprintf("Новый отклик на вакансию «%s»", vacancy.Title),
+			Body:   "Студент откликнулся на вашу вакансию.",
+			Data: map[string]interface{}{
+				"application_id": application.ID,
+				"vacancy_id":     vacancy.ID,
+				"student_id":     application.StudentID,
+			},
+		}
+	}
+	publishNotifications(ctx, s.publisher, notifications...)
+}
+
+func applicationStatusTitle(status string) string {
+	if title, ok := applicationStatusTitles[status]; ok {
+		return title
+	}
+	return status
+}
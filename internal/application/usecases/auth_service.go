@@ -3,10 +3,13 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
 	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
 	"github.com/albkvv/student-job-finder-back/internal/utils"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -15,55 +18,69 @@ const (
 	otpLength     = 6
 	otpExpiration = 5 * time.Minute
 	maxOTPAttempts = 5
+
+	// codeTypeReset тип кода для сброса пароля, хранится отдельно от кодов входа
+	codeTypeReset = "reset"
+)
+
+var (
+	// ErrInvalidRefreshToken возвращается для неизвестного, отозванного при выходе или просроченного refresh-токена
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrRefreshTokenReused возвращается при повторном использовании уже ротированного refresh-токена
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected, session revoked")
 )
 
 type AuthService struct {
-	UserRepo repositories.UserRepository
-	CodeRepo repositories.VerificationCodeRepository
+	UserRepo    repositories.UserRepository
+	CodeRepo    repositories.VerificationCodeRepository
+	RefreshRepo repositories.RefreshTokenRepository
+	SMS         services.SMSSender
+	Email       services.EmailSender
+	Limiter     *OTPLimiter
 }
 
-func NewAuthService(u repositories.UserRepository, c repositories.VerificationCodeRepository) *AuthService {
-	return &AuthService{UserRepo: u, CodeRepo: c}
+func NewAuthService(u repositories.UserRepository, c repositories.VerificationCodeRepository, r repositories.RefreshTokenRepository, sms services.SMSSender, email services.EmailSender, limiter *OTPLimiter) *AuthService {
+	return &AuthService{UserRepo: u, CodeRepo: c, RefreshRepo: r, SMS: sms, Email: email, Limiter: limiter}
 }
-func (a *AuthService) RegisterPassword(ctx context.Context, email, phone, password, role string) (*entities.User, string, error) {
+func (a *AuthService) RegisterPassword(ctx context.Context, email, phone, password, role string) (*entities.User, *entities.TokenPair, error) {
 	if email == "" && phone == "" {
-		return nil, "", errors.New("email or phone is required")
+		return nil, nil, errors.New("email or phone is required")
 	}
 	
 	if err := utils.ValidatePassword(password); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
 	if role == "" {
 		role = "student"
 	}
 	if err := utils.ValidateRole(role); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
 	if email != "" {
 		if err := utils.ValidateEmail(email); err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 		existing, _ := a.UserRepo.FindByEmail(ctx, email)
 		if existing != nil {
-			return nil, "", errors.New("email already registered")
+			return nil, nil, errors.New("email already registered")
 		}
 	}
 	
 	if phone != "" {
 		if err := utils.ValidatePhone(phone); err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 		existing, _ := a.UserRepo.FindByPhone(ctx, phone)
 		if existing != nil {
-			return nil, "", errors.New("phone already registered")
+			return nil, nil, errors.New("phone already registered")
 		}
 	}
 	
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
 	newUser := &entities.User{
@@ -75,22 +92,23 @@ func (a *AuthService) RegisterPassword(ctx context.Context, email, phone, passwo
 		IsVerified:   false,
 		CreatedAt:    time.Now(),
 	}
+	newUser.ContactPreferences = entities.DefaultContactPreferences(newUser)
 	
 	if err := a.UserRepo.Create(ctx, newUser); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
-	token, err := utils.GenerateJWT(newUser.ID)
+	tokens, err := a.issueTokens(ctx, newUser.ID, "")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
-	return newUser, token, nil
+	return newUser, tokens, nil
 }
 
-func (a *AuthService) LoginPassword(ctx context.Context, identifier, password string) (*entities.User, string, error) {
+func (a *AuthService) LoginPassword(ctx context.Context, identifier, password string) (*entities.User, *entities.TokenPair, error) {
 	if identifier == "" || password == "" {
-		return nil, "", errors.New("identifier and password are required")
+		return nil, nil, errors.New("identifier and password are required")
 	}
 	
 	var foundUser *entities.User
@@ -101,26 +119,26 @@ func (a *AuthService) LoginPassword(ctx context.Context, identifier, password st
 	} else if utils.ValidatePhone(identifier) == nil {
 		foundUser, err = a.UserRepo.FindByPhone(ctx, identifier)
 	} else {
-		return nil, "", errors.New("invalid identifier format")
+		return nil, nil, errors.New("invalid identifier format")
 	}
 	
 	if err != nil || foundUser == nil {
-		return nil, "", errors.New("invalid credentials")
+		return nil, nil, errors.New("invalid credentials")
 	}
 	
 	if err := bcrypt.CompareHashAndPassword([]byte(foundUser.PasswordHash), []byte(password)); err != nil {
-		return nil, "", errors.New("invalid credentials")
+		return nil, nil, errors.New("invalid credentials")
 	}
 	
-	token, err := utils.GenerateJWT(foundUser.ID)
+	tokens, err := a.issueTokens(ctx, foundUser.ID, "")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
-	return foundUser, token, nil
+	return foundUser, tokens, nil
 }
 
-func (a *AuthService) RequestPhoneCode(ctx context.Context, phone, role string) error {
+func (a *AuthService) RequestPhoneCode(ctx context.Context, phone, role, clientIP string) error {
 	if err := utils.ValidatePhone(phone); err != nil {
 		return err
 	}
@@ -132,141 +150,408 @@ func (a *AuthService) RequestPhoneCode(ctx context.Context, phone, role string)
 		return err
 	}
 	
-	user, _ := a.UserRepo.FindByPhone(ctx, phone)
-	if user == nil {
-		newUser := &entities.User{
-			ID:         utils.GenerateID(),
-			Phone:      phone,
-			Role:       role,
-			IsVerified: false,
-			CreatedAt:  time.Now(),
-		}
-		a.UserRepo.Create(ctx, newUser)
+	if err := a.Limiter.AllowRequest(ctx, phone, clientIP); err != nil {
+		return err
 	}
 	
+	// Пользователь создается только после подтверждения кода, роль хранится вместе с кодом
 	code := utils.GenerateNumericCode(otpLength)
-	exp := time.Now().Add(otpExpiration).Unix()
-	
-	if err := a.CodeRepo.SetCode(ctx, phone, code, "phone", exp); err != nil {
+	if err := a.CodeRepo.SetCode(ctx, &entities.VerificationCode{
+		Identifier:  phone,
+		CodeHash:    utils.HashCode(phone, code),
+		Type:        "phone",
+		ExpiresAt:   time.Now().Add(otpExpiration).Unix(),
+		PendingRole: role,
+	}); err != nil {
 		return err
 	}
 	
-	utils.LogSMSToConsole(phone, code)
-	return nil
+	return a.SMS.SendSMS(ctx, phone, fmt.Sprintf("Код подтверждения Student Job Finder: %s", code))
 }
 
-func (a *AuthService) RequestEmailCode(ctx context.Context, email string) error {
+func (a *AuthService) RequestEmailCode(ctx context.Context, email, role, clientIP string) error {
 	if err := utils.ValidateEmail(email); err != nil {
 		return err
 	}
 	
-	user, _ := a.UserRepo.FindByEmail(ctx, email)
-	if user == nil {
-		newUser := &entities.User{
-			ID:         utils.GenerateID(),
-			Email:      email,
-			Role:       "student",
-			IsVerified: false,
-			CreatedAt:  time.Now(),
-		}
-		a.UserRepo.Create(ctx, newUser)
+	if role == "" {
+		role = "student"
+	}
+	if err := utils.ValidateRole(role); err != nil {
+		return err
 	}
 	
-	code := utils.GenerateNumericCode(otpLength)
-	exp := time.Now().Add(otpExpiration).Unix()
+	if err := a.Limiter.AllowRequest(ctx, email, clientIP); err != nil {
+		return err
+	}
 	
-	if err := a.CodeRepo.SetCode(ctx, email, code, "email", exp); err != nil {
+	// Пользователь создается только после подтверждения кода, роль хранится вместе с кодом
+	code := utils.GenerateNumericCode(otpLength)
+	if err := a.CodeRepo.SetCode(ctx, &entities.VerificationCode{
+		Identifier:  email,
+		CodeHash:    utils.HashCode(email, code),
+		Type:        "email",
+		ExpiresAt:   time.Now().Add(otpExpiration).Unix(),
+		PendingRole: role,
+	}); err != nil {
 		return err
 	}
 	
-	utils.LogSMSToConsole(email, code)
-	return nil
+	return a.Email.SendEmail(ctx, email, "Код подтверждения",
+		fmt.Sprintf("Ваш код подтверждения: %s\nКод действует %d минут.", code, int(otpExpiration.Minutes())))
 }
-func (a *AuthService) VerifyPhoneCode(ctx context.Context, phone, code string) (*entities.User, string, error) {
+func (a *AuthService) VerifyPhoneCode(ctx context.Context, phone, code string) (*entities.User, *entities.TokenPair, error) {
 	if err := utils.ValidatePhone(phone); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
 	if len(code) != otpLength {
-		return nil, "", errors.New("invalid code format")
+		return nil, nil, errors.New("invalid code format")
+	}
+	
+	if err := a.Limiter.CheckVerification(ctx, phone); err != nil {
+		return nil, nil, err
 	}
 	
 	vc, err := a.CodeRepo.GetCode(ctx, phone, "phone")
 	if err != nil || vc == nil {
-		return nil, "", errors.New("code not found or expired")
+		return nil, nil, errors.New("code not found or expired")
 	}
 	
 	if time.Now().Unix() > vc.ExpiresAt {
-		return nil, "", errors.New("code expired")
+		return nil, nil, errors.New("code expired")
 	}
 	
 	if vc.Attempts >= maxOTPAttempts {
-		return nil, "", errors.New("maximum attempts exceeded")
+		return nil, nil, errors.New("maximum attempts exceeded")
 	}
 	
-	if vc.Code != code {
+	if !utils.CheckCode(phone, code, vc.CodeHash) {
 		a.CodeRepo.IncrementAttempts(ctx, phone, "phone")
-		return nil, "", errors.New("invalid code")
+		if err := a.Limiter.RecordFailure(ctx, phone); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("invalid code")
 	}
+	a.Limiter.RecordSuccess(ctx, phone)
 	
 	user, err := a.UserRepo.FindByPhone(ctx, phone)
-	if err != nil || user == nil {
-		return nil, "", errors.New("user not found")
+	if err != nil {
+		return nil, nil, err
 	}
 	
-	user.IsVerified = true
-	a.UserRepo.Update(ctx, user)
+	if user == nil {
+		user, err = a.createVerifiedUser(ctx, &entities.User{Phone: phone}, vc.PendingRole)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if !user.IsVerified {
+		user.IsVerified = true
+		if err := a.UserRepo.Update(ctx, user); err != nil {
+			return nil, nil, err
+		}
+	}
 	a.CodeRepo.DeleteCode(ctx, phone, "phone")
 	
-	token, err := utils.GenerateJWT(user.ID)
+	tokens, err := a.issueTokens(ctx, user.ID, "")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
-	return user, token, nil
+	return user, tokens, nil
 }
 
-func (a *AuthService) VerifyEmailCode(ctx context.Context, email, code string) (*entities.User, string, error) {
+func (a *AuthService) VerifyEmailCode(ctx context.Context, email, code string) (*entities.User, *entities.TokenPair, error) {
 	if err := utils.ValidateEmail(email); err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
 	if len(code) != otpLength {
-		return nil, "", errors.New("invalid code format")
+		return nil, nil, errors.New("invalid code format")
+	}
+	
+	if err := a.Limiter.CheckVerification(ctx, email); err != nil {
+		return nil, nil, err
 	}
 	
 	vc, err := a.CodeRepo.GetCode(ctx, email, "email")
 	if err != nil || vc == nil {
-		return nil, "", errors.New("code not found or expired")
+		return nil, nil, errors.New("code not found or expired")
 	}
 	
 	if time.Now().Unix() > vc.ExpiresAt {
-		return nil, "", errors.New("code expired")
+		return nil, nil, errors.New("code expired")
 	}
 	
 	if vc.Attempts >= maxOTPAttempts {
-		return nil, "", errors.New("maximum attempts exceeded")
+		return nil, nil, errors.New("maximum attempts exceeded")
 	}
 	
-	if vc.Code != code {
+	if !utils.CheckCode(email, code, vc.CodeHash) {
 		a.CodeRepo.IncrementAttempts(ctx, email, "email")
-		return nil, "", errors.New("invalid code")
+		if err := a.Limiter.RecordFailure(ctx, email); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("invalid code")
 	}
+	a.Limiter.RecordSuccess(ctx, email)
 	
 	user, err := a.UserRepo.FindByEmail(ctx, email)
-	if err != nil || user == nil {
-		return nil, "", errors.New("user not found")
+	if err != nil {
+		return nil, nil, err
 	}
 	
-	user.IsVerified = true
-	a.UserRepo.Update(ctx, user)
+	if user == nil {
+		user, err = a.createVerifiedUser(ctx, &entities.User{Email: email}, vc.PendingRole)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if !user.IsVerified {
+		user.IsVerified = true
+		if err := a.UserRepo.Update(ctx, user); err != nil {
+			return nil, nil, err
+		}
+	}
 	a.CodeRepo.DeleteCode(ctx, email, "email")
 	
-	token, err := utils.GenerateJWT(user.ID)
+	tokens, err := a.issueTokens(ctx, user.ID, "")
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	
-	return user, token, nil
+	return user, tokens, nil
+}
+
+// RefreshTokens обменивает refresh-токен на новую пару токенов (ротация).
+// Повторное предъявление уже использованного токена отзывает всю сессию.
+func (a *AuthService) RefreshTokens(ctx context.Context, refreshToken string) (*entities.User, *entities.TokenPair, error) {
+	if refreshToken == "" {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	stored, err := a.RefreshRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, nil, err
+	}
+	if stored == nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	if stored.RevokedAt != nil {
+		// Токен уже был ротирован: вероятно, он украден, поэтому отзываем всё семейство
+		if stored.ReplacedBy != "" {
+			a.RefreshRepo.RevokeFamily(ctx, stored.FamilyID)
+			return nil, nil, ErrRefreshTokenReused
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	user, err := a.UserRepo.FindByID(ctx, stored.UserID)
+	if err != nil || user == nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	tokens, err := a.issueTokens(ctx, user.ID, stored.FamilyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Параллельный запрос с тем же токеном успел ротировать его раньше — это тоже повторное использование
+	if err := a.RefreshRepo.Revoke(ctx, stored.ID, utils.HashToken(tokens.RefreshToken)); err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenRevoked) {
+			a.RefreshRepo.RevokeFamily(ctx, stored.FamilyID)
+			return nil, nil, ErrRefreshTokenReused
+		}
+		return nil, nil, err
+	}
+
+	return user, tokens, nil
+}
+
+// Logout отзывает текущий refresh-токен
+func (a *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	if refreshToken == "" {
+		return ErrInvalidRefreshToken
+	}
+
+	stored, err := a.RefreshRepo.FindByHash(ctx, utils.HashToken(refreshToken))
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return ErrInvalidRefreshToken
+	}
+
+	if err := a.RefreshRepo.Revoke(ctx, stored.ID, ""); err != nil && !errors.Is(err, repositories.ErrRefreshTokenRevoked) {
+		return err
+	}
+	return nil
+}
+
+// issueTokens выпускает access-токен и сохраняет новый refresh-токен.
+// Пустой familyID начинает новую сессию.
+func (a *AuthService) issueTokens(ctx context.Context, userID, familyID string) (*entities.TokenPair, error) {
+	accessToken, accessExpiresAt, err := utils.GenerateJWT(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if familyID == "" {
+		familyID = utils.GenerateID()
+	}
+
+	stored := &entities.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	}
+	if err := a.RefreshRepo.Create(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return &entities.TokenPair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  accessExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// RequestPasswordReset отправляет код сброса пароля на email или телефон.
+// Для незарегистрированного идентификатора ошибка не возвращается, чтобы не раскрывать наличие аккаунта.
+func (a *AuthService) RequestPasswordReset(ctx context.Context, identifier, clientIP string) error {
+	if utils.ValidateEmail(identifier) != nil && utils.ValidatePhone(identifier) != nil {
+		return errors.New("invalid identifier format")
+	}
+
+	// Лимиты применяются до поиска пользователя, чтобы ответ не зависел от наличия аккаунта
+	if err := a.Limiter.AllowRequest(ctx, identifier, clientIP); err != nil {
+		return err
+	}
+
+	user, isEmail, err := a.findByIdentifier(ctx, identifier)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	// Сбои сохранения и отправки только логируются: ответ для существующего аккаунта
+	// не должен отличаться от ответа для незарегистрированного идентификатора
+	code := utils.GenerateNumericCode(otpLength)
+	if err := a.CodeRepo.SetCode(ctx, &entities.VerificationCode{
+		Identifier: identifier,
+		CodeHash:   utils.HashCode(identifier, code),
+		Type:       codeTypeReset,
+		ExpiresAt:  time.Now().Add(otpExpiration).Unix(),
+	}); err != nil {
+		log.Printf("failed to store password reset code: %v", err)
+		return nil
+	}
+
+	if isEmail {
+		err = a.Email.SendEmail(ctx, identifier, "Сброс пароля",
+			fmt.Sprintf("Код для сброса пароля: %s\nКод действует %d минут. Если вы не запрашивали сброс, проигнорируйте это письмо.", code, int(otpExpiration.Minutes())))
+	} else {
+		err = a.SMS.SendSMS(ctx, identifier, fmt.Sprintf("Код для сброса пароля Student Job Finder: %s", code))
+	}
+	if err != nil {
+		log.Printf("failed to send password reset code: %v", err)
+	}
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по коду сброса и завершает все активные сессии пользователя
+func (a *AuthService) ResetPassword(ctx context.Context, identifier, code, newPassword string) error {
+	if len(code) != otpLength {
+		return errors.New("invalid code format")
+	}
+	if err := utils.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	if err := a.Limiter.CheckVerification(ctx, identifier); err != nil {
+		return err
+	}
+
+	vc, err := a.CodeRepo.GetCode(ctx, identifier, codeTypeReset)
+	if err != nil || vc == nil {
+		return errors.New("invalid or expired code")
+	}
+
+	if time.Now().Unix() > vc.ExpiresAt {
+		return errors.New("invalid or expired code")
+	}
+
+	if vc.Attempts >= maxOTPAttempts {
+		return errors.New("maximum attempts exceeded")
+	}
+
+	if !utils.CheckCode(identifier, code, vc.CodeHash) {
+		a.CodeRepo.IncrementAttempts(ctx, identifier, codeTypeReset)
+		if err := a.Limiter.RecordFailure(ctx, identifier); err != nil {
+			return err
+		}
+		return errors.New("invalid or expired code")
+	}
+	a.Limiter.RecordSuccess(ctx, identifier)
+
+	user, _, err := a.findByIdentifier(ctx, identifier)
+	if err != nil || user == nil {
+		return errors.New("invalid or expired code")
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := a.UserRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	a.CodeRepo.DeleteCode(ctx, identifier, codeTypeReset)
+
+	return a.RefreshRepo.RevokeAllForUser(ctx, user.ID)
 }
 
+// createVerifiedUser создает пользователя, подтвердившего email или телефон кодом
+func (a *AuthService) createVerifiedUser(ctx context.Context, user *entities.User, role string) (*entities.User, error) {
+	if role == "" {
+		role = "student"
+	}
+	user.ID = utils.GenerateID()
+	user.Role = role
+	user.IsVerified = true
+	user.CreatedAt = time.Now()
+	user.ContactPreferences = entities.DefaultContactPreferences(user)
+
+	if err := a.UserRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// findByIdentifier ищет пользователя по email или телефону; isEmail сообщает, что идентификатор — email
+func (a *AuthService) findByIdentifier(ctx context.Context, identifier string) (*entities.User, bool, error) {
+	if utils.ValidateEmail(identifier) == nil {
+		user, err := a.UserRepo.FindByEmail(ctx, identifier)
+		return user, true, err
+	}
+	if utils.ValidatePhone(identifier) == nil {
+		user, err := a.UserRepo.FindByPhone(ctx, identifier)
+		return user, false, err
+	}
+	return nil, false, errors.New("invalid identifier format")
+}
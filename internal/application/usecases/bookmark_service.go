@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+type BookmarkService struct {
+	repo        repositories.BookmarkRepository
+	vacancyRepo repositories.VacancyRepository
+	companyRepo repositories.CompanyRepository
+}
+
+func NewBookmarkService(repo repositories.BookmarkRepository, vacancyRepo repositories.VacancyRepository, companyRepo repositories.CompanyRepository) *BookmarkService {
+	return &BookmarkService{
+		repo:        repo,
+		vacancyRepo: vacancyRepo,
+		companyRepo: companyRepo,
+	}
+}
+
+// SaveVacancy добавляет вакансию в закладки студента
+func (s *BookmarkService) SaveVacancy(ctx context.Context, userID, vacancyID string) (*entities.Bookmark, error) {
+	vacancy, err := s.vacancyRepo.FindByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vacancy == nil {
+		return nil, errors.New("vacancy not found")
+	}
+
+	bookmark := &entities.Bookmark{
+		UserID:    userID,
+		VacancyID: vacancy.ID,
+	}
+	if err := s.repo.Create(ctx, bookmark); err != nil {
+		return nil, err
+	}
+	return bookmark, nil
+}
+
+// RemoveVacancy удаляет вакансию из закладок студента
+func (s *BookmarkService) RemoveVacancy(ctx context.Context, userID, vacancyID string) error {
+	return s.repo.Delete(ctx, userID, vacancyID)
+}
+
+// GetSavedVacancies возвращает сохраненные вакансии, новые закладки первыми.
+// Закрытые вакансии и вакансии с прошедшим дедлайном помечаются причиной недоступности,
+// закладки на удаленные вакансии пропускаются.
+func (s *BookmarkService) GetSavedVacancies(ctx context.Context, userID string) ([]*entities.SavedVacancy, error) {
+	bookmarks, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		ids[i] = bookmark.VacancyID
+	}
+	vacancies, err := s.vacancyRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	saved := make([]*entities.SavedVacancy, 0, len(bookmarks))
+	found := make([]*entities.Vacancy, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		vacancy, ok := vacancies[bookmark.VacancyID]
+		if !ok {
+			continue
+		}
+		isSaved := true
+		vacancy.IsSaved = &isSaved
+		found = append(found, vacancy)
+		saved = append(saved, &entities.SavedVacancy{
+			Vacancy:     vacancy,
+			SavedAt:     bookmark.CreatedAt,
+			Unavailable: vacancy.UnavailableReason(now),
+		})
+	}
+	if err := attachCompanies(ctx, s.companyRepo, found...); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// MarkSaved заполняет у вакансий флаг IsSaved для студента одним запросом к хранилищу закладок
+func (s *BookmarkService) MarkSaved(ctx context.Context, userID string, vacancies ...*entities.Vacancy) error {
+	ids := make([]string, len(vacancies))
+	for i, vacancy := range vacancies {
+		ids[i] = vacancy.ID
+	}
+	saved, err := s.repo.SavedVacancyIDs(ctx, userID, ids)
+	if err != nil {
+		return err
+	}
+	for _, vacancy := range vacancies {
+		isSaved := saved[vacancy.ID]
+		vacancy.IsSaved = &isSaved
+	}
+	return nil
+}
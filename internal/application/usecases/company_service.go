@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+const (
+	maxCompanyNameLength        = 150
+	maxCompanyDescriptionLength = 5000
+	maxCompanySocialLinks       = 10
+)
+
+// ErrCompanyHasVacancies возвращается при удалении компании, к которой привязаны вакансии
+var ErrCompanyHasVacancies = errors.New("company has vacancies, delete or move them first")
+
+type CompanyService struct {
+	repo        repositories.CompanyRepository
+	vacancyRepo repositories.VacancyRepository
+	codeRepo    repositories.VerificationCodeRepository
+	sms         services.SMSSender
+	email       services.EmailSender
+	limiter     *OTPLimiter
+	access      vacancyAccess
+}
+
+func NewCompanyService(repo repositories.CompanyRepository, vacancyRepo repositories.VacancyRepository,
+	codeRepo repositories.VerificationCodeRepository, sms services.SMSSender, email services.EmailSender, limiter *OTPLimiter) *CompanyService {
+	return &CompanyService{
+		repo:        repo,
+		vacancyRepo: vacancyRepo,
+		codeRepo:    codeRepo,
+		sms:         sms,
+		email:       email,
+		limiter:     limiter,
+		access:      vacancyAccess{companyRepo: repo},
+	}
+}
+
+// CreateCompany создает компанию; создатель становится ее владельцем
+func (s *CompanyService) CreateCompany(ctx context.Context, userID string, company *entities.Company) error {
+	if err := normalizeCompany(company); err != nil {
+		return err
+	}
+	company.Members = []entities.CompanyMember{
+		{UserID: userID, Role: entities.CompanyRoleOwner, JoinedAt: time.Now()},
+	}
+	return s.repo.Create(ctx, company)
+}
+
+// GetCompany получает компанию по ID
+func (s *CompanyService) GetCompany(ctx context.Context, id string) (*entities.Company, error) {
+	company, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil {
+		return nil, errors.New("company not found")
+	}
+	return company, nil
+}
+
+// GetCompanyVacancies получает страницу активных вакансий компании с непрошедшим дедлайном, новые первыми
+func (s *CompanyService) GetCompanyVacancies(ctx context.Context, id string, limit int, cursor string) (*repositories.VacancyPage, error) {
+	if limit <= 0 {
+		limit = defaultVacancyPageLimit
+	}
+	if limit > maxVacancyPageLimit {
+		limit = maxVacancyPageLimit
+	}
+
+	return s.vacancyRepo.FindAll(ctx, repositories.VacancyFilter{
+		CompanyID:  id,
+		Status:     entities.VacancyStatusActive,
+		NotExpired: true,
+	}, repositories.VacancyPageRequest{
+		Limit:  limit,
+		Cursor: cursor,
+		Sort:   repositories.VacancySortNewest,
+	})
+}
+
+// GetUserCompanies получает компании, в команде которых состоит пользователь
+func (s *CompanyService) GetUserCompanies(ctx context.Context, userID string) ([]*entities.Company, error) {
+	return s.repo.FindByMember(ctx, userID)
+}
+
+// UpdateCompany обновляет описание компании; доступно владельцам и администраторам
+func (s *CompanyService) UpdateCompany(ctx context.Context, userID string, company *entities.Company) error {
+	if _, err := s.access.company(ctx, userID, company.ID, entities.CompanyPermManageCompany); err != nil {
+		return err
+	}
+	if err := normalizeCompany(company); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, company)
+}
+
+// DeleteCompany удаляет компанию без вакансий; доступно только владельцам
+func (s *CompanyService) DeleteCompany(ctx context.Context, userID, id string) error {
+	company, err := s.GetCompany(ctx, id)
+	if err != nil {
+		return err
+	}
+	if company.MemberRole(userID) != entities.CompanyRoleOwner {
+		return ErrCompanyForbidden
+	}
+
+	page, err := s.vacancyRepo.FindAll(ctx, repositories.VacancyFilter{CompanyID: id}, repositories.VacancyPageRequest{
+		Limit: 1,
+		Sort:  repositories.VacancySortNewest,
+	})
+	if err != nil {
+		return err
+	}
+	if page.Total > 0 {
+		return ErrCompanyHasVacancies
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// normalizeCompany проверяет поля компании и обрезает пробелы
+func normalizeCompany(c *entities.Company) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Industry = strings.TrimSpace(c.Industry)
+	c.City = strings.TrimSpace(c.City)
+
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(c.Name) > maxCompanyNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxCompanyNameLength)
+	}
+	if utf8.RuneCountInString(c.Description) > maxCompanyDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", maxCompanyDescriptionLength)
+	}
+
+	switch c.Size {
+	case "", entities.CompanySizeMicro, entities.CompanySizeSmall, entities.CompanySizeMedium,
+		entities.CompanySizeLarge, entities.CompanySizeHuge:
+	default:
+		return errors.New("invalid size, must be '1-10', '11-50', '51-200', '201-1000' or '1000+'")
+	}
+
+	if c.LogoURL != "" {
+		if err := utils.ValidateHTTPURL(c.LogoURL); err != nil {
+			return fmt.Errorf("logo_url %w", err)
+		}
+	}
+	if c.Website != "" {
+		if err := utils.ValidateHTTPURL(c.Website); err != nil {
+			return fmt.Errorf("website %w", err)
+		}
+	}
+
+	if c.SocialLinks == nil {
+		c.SocialLinks = []entities.SocialLink{}
+	}
+	if len(c.SocialLinks) > maxCompanySocialLinks {
+		return fmt.Errorf("at most %d social links are allowed", maxCompanySocialLinks)
+	}
+	for i := range c.SocialLinks {
+		link := &c.SocialLinks[i]
+		link.Network = strings.ToLower(strings.TrimSpace(link.Network))
+		if link.Network == "" {
+			return errors.New("social link network is required")
+		}
+		if err := utils.ValidateHTTPURL(link.URL); err != nil {
+			return fmt.Errorf("social link '%s' url %w", link.Network, err)
+		}
+	}
+
+	return nil
+}
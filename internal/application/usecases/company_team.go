@@ -0,0 +1,205 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+const (
+	invitationExpiration = 7 * 24 * time.Hour
+
+	// codeTypeInvite префикс типа кода приглашения; к нему добавляется ID компании
+	codeTypeInvite = "invite:"
+)
+
+// InviteMember приглашает пользователя в команду по email или телефону.
+// Приглашение хранится как код подтверждения, роль в команде — в PendingRole кода.
+// Владельцев может приглашать только владелец.
+func (s *CompanyService) InviteMember(ctx context.Context, userID, companyID, identifier, role, clientIP string) (*entities.CompanyInvitation, error) {
+	company, err := s.access.company(ctx, userID, companyID, entities.CompanyPermManageCompany)
+	if err != nil {
+		return nil, err
+	}
+	if !entities.IsValidCompanyRole(role) {
+		return nil, errors.New("invalid role, must be 'owner', 'admin', 'recruiter' or 'viewer'")
+	}
+	if role == entities.CompanyRoleOwner && company.MemberRole(userID) != entities.CompanyRoleOwner {
+		return nil, ErrCompanyForbidden
+	}
+
+	isEmail := utils.ValidateEmail(identifier) == nil
+	if !isEmail && utils.ValidatePhone(identifier) != nil {
+		return nil, errors.New("invalid identifier format")
+	}
+
+	if err := s.limiter.AllowRequest(ctx, identifier, clientIP); err != nil {
+		return nil, err
+	}
+
+	code := utils.GenerateNumericCode(otpLength)
+	invitation := &entities.CompanyInvitation{
+		CompanyID:  company.ID,
+		Identifier: identifier,
+		Role:       role,
+		ExpiresAt:  time.Now().Add(invitationExpiration),
+	}
+	if err := s.codeRepo.SetCode(ctx, &entities.VerificationCode{
+		Identifier:  identifier,
+		CodeHash:    utils.HashCode(identifier, code),
+		Type:        codeTypeInvite + company.ID,
+		ExpiresAt:   invitation.ExpiresAt.Unix(),
+		PendingRole: role,
+	}); err != nil {
+		return nil, err
+	}
+
+	if isEmail {
+		err = s.email.SendEmail(ctx, identifier, fmt.Sprintf("Приглашение в команду %s", company.Name),
+			fmt.Sprintf("Вас пригласили в команду компании %s в Student Job Finder.\nКод приглашения: %s\nКод действует %d дней.",
+				company.Name, code, int(invitationExpiration.Hours()/24)))
+	} else {
+		err = s.sms.SendSMS(ctx, identifier, fmt.Sprintf("Приглашение в команду %s на Student Job Finder, код: %s", company.Name, code))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation отменяет неиспользованное приглашение
+func (s *CompanyService) RevokeInvitation(ctx context.Context, userID, companyID, identifier string) error {
+	if _, err := s.access.company(ctx, userID, companyID, entities.CompanyPermManageCompany); err != nil {
+		return err
+	}
+	return s.codeRepo.DeleteCode(ctx, identifier, codeTypeInvite+companyID)
+}
+
+// AcceptInvitation добавляет пользователя в команду по коду, отправленному на его email или телефон
+func (s *CompanyService) AcceptInvitation(ctx context.Context, user *entities.User, companyID, code string) (*entities.Company, error) {
+	if user.Role != entities.UserRoleEmployer {
+		return nil, errors.New("only employers can join a company team")
+	}
+	if len(code) != otpLength {
+		return nil, errors.New("invalid code format")
+	}
+
+	company, err := s.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	codeType := codeTypeInvite + company.ID
+	for _, identifier := range []string{user.Email, user.Phone} {
+		if identifier == "" {
+			continue
+		}
+		if err := s.limiter.CheckVerification(ctx, identifier); err != nil {
+			return nil, err
+		}
+
+		vc, err := s.codeRepo.GetCode(ctx, identifier, codeType)
+		if err != nil {
+			return nil, err
+		}
+		if vc == nil || time.Now().Unix() > vc.ExpiresAt {
+			continue
+		}
+		if vc.Attempts >= maxOTPAttempts {
+			return nil, errors.New("maximum attempts exceeded")
+		}
+
+		if !utils.CheckCode(identifier, code, vc.CodeHash) {
+			s.codeRepo.IncrementAttempts(ctx, identifier, codeType)
+			if err := s.limiter.RecordFailure(ctx, identifier); err != nil {
+				return nil, err
+			}
+			return nil, errors.New("invalid or expired invitation code")
+		}
+		s.limiter.RecordSuccess(ctx, identifier)
+
+		if company.MemberRole(user.ID) == entities.CompanyRoleOwner && vc.PendingRole != entities.CompanyRoleOwner &&
+			company.CountRole(entities.CompanyRoleOwner) == 1 {
+			return nil, errors.New("company must have at least one owner")
+		}
+		if err := s.setMemberRole(ctx, company, user.ID, vc.PendingRole); err != nil {
+			return nil, err
+		}
+		s.codeRepo.DeleteCode(ctx, identifier, codeType)
+		return s.GetCompany(ctx, company.ID)
+	}
+
+	return nil, errors.New("invalid or expired invitation code")
+}
+
+// ChangeMemberRole меняет роль участника команды.
+// Назначать и снимать владельцев может только владелец; последнего владельца понизить нельзя.
+func (s *CompanyService) ChangeMemberRole(ctx context.Context, userID, companyID, memberID, role string) (*entities.Company, error) {
+	company, err := s.access.company(ctx, userID, companyID, entities.CompanyPermManageCompany)
+	if err != nil {
+		return nil, err
+	}
+	if !entities.IsValidCompanyRole(role) {
+		return nil, errors.New("invalid role, must be 'owner', 'admin', 'recruiter' or 'viewer'")
+	}
+
+	current := company.MemberRole(memberID)
+	if current == "" {
+		return nil, errors.New("user is not a company member")
+	}
+	if (role == entities.CompanyRoleOwner || current == entities.CompanyRoleOwner) &&
+		company.MemberRole(userID) != entities.CompanyRoleOwner {
+		return nil, ErrCompanyForbidden
+	}
+	if current == entities.CompanyRoleOwner && role != entities.CompanyRoleOwner && company.CountRole(entities.CompanyRoleOwner) == 1 {
+		return nil, errors.New("company must have at least one owner")
+	}
+
+	if err := s.setMemberRole(ctx, company, memberID, role); err != nil {
+		return nil, err
+	}
+	return s.GetCompany(ctx, company.ID)
+}
+
+// RemoveMember исключает участника из команды. Участник может покинуть команду сам.
+func (s *CompanyService) RemoveMember(ctx context.Context, userID, companyID, memberID string) (*entities.Company, error) {
+	company, err := s.GetCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := company.MemberRole(memberID)
+	if current == "" {
+		return nil, errors.New("user is not a company member")
+	}
+	if userID != memberID {
+		if !company.Can(userID, entities.CompanyPermManageCompany) {
+			return nil, ErrCompanyForbidden
+		}
+		if current == entities.CompanyRoleOwner && company.MemberRole(userID) != entities.CompanyRoleOwner {
+			return nil, ErrCompanyForbidden
+		}
+	}
+	if current == entities.CompanyRoleOwner && company.CountRole(entities.CompanyRoleOwner) == 1 {
+		return nil, errors.New("company must have at least one owner")
+	}
+
+	if err := s.repo.RemoveMember(ctx, company.ID, memberID, current); err != nil {
+		return nil, err
+	}
+	return s.GetCompany(ctx, company.ID)
+}
+
+// setMemberRole добавляет пользователя в команду или меняет его роль.
+// Изменение применяется, только если роль участника не изменилась с момента чтения компании.
+func (s *CompanyService) setMemberRole(ctx context.Context, company *entities.Company, userID, role string) error {
+	current := company.MemberRole(userID)
+	if current == "" {
+		return s.repo.AddMember(ctx, company.ID, entities.CompanyMember{UserID: userID, Role: role, JoinedAt: time.Now()})
+	}
+	return s.repo.SetMemberRole(ctx, company.ID, userID, current, role)
+}
@@ -0,0 +1,496 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+const (
+	maxMessageLength             = 5000
+	maxMessageAttachments        = 10
+	defaultMessagePageLimit      = 50
+	maxMessagePageLimit          = 100
+	defaultConversationPageLimit = 20
+	maxConversationPageLimit     = 100
+)
+
+var (
+	// ErrConversationNotAllowed возвращается, если переписку по вакансии начать нельзя:
+	// студент должен откликнуться, а работодатель может писать откликнувшимся и открытым для подбора студентам
+	ErrConversationNotAllowed = errors.New("conversation about this vacancy is not allowed")
+	// ErrNotMessageSender возвращается при попытке изменить или удалить чужое сообщение
+	ErrNotMessageSender = errors.New("only the sender can change the message")
+)
+
+type ConversationService struct {
+	repo            repositories.ConversationRepository
+	messageRepo     repositories.MessageRepository
+	vacancyRepo     repositories.VacancyRepository
+	applicationRepo repositories.ApplicationRepository
+	profileRepo     repositories.StudentProfileRepository
+	companyRepo     repositories.CompanyRepository
+	broker          services.EventBroker
+	access          vacancyAccess
+}
+
+func NewConversationService(repo repositories.ConversationRepository, messageRepo repositories.MessageRepository,
+	vacancyRepo repositories.VacancyRepository, applicationRepo repositories.ApplicationRepository,
+	profileRepo repositories.StudentProfileRepository, companyRepo repositories.CompanyRepository,
+	broker services.EventBroker) *ConversationService {
+	return &ConversationService{
+		repo:            repo,
+		messageRepo:     messageRepo,
+		vacancyRepo:     vacancyRepo,
+		applicationRepo: applicationRepo,
+		profileRepo:     profileRepo,
+		companyRepo:     companyRepo,
+		broker:          broker,
+		access:          vacancyAccess{companyRepo: companyRepo},
+	}
+}
+
+// StartConversation открывает переписку по паре (студент, вакансия) или возвращает существующую.
+// Студент пишет по вакансии, на которую откликнулся; работодатель с правом просмотра откликов —
+// откликнувшемуся студенту или студенту, открытому для подбора.
+func (s *ConversationService) StartConversation(ctx context.Context, user *entities.User, vacancyID, studentID string) (*entities.Conversation, error) {
+	vacancy, err := s.vacancyRepo.FindByID(ctx, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+	if vacancy == nil {
+		return nil, errors.New("vacancy not found")
+	}
+
+	if user.Role == entities.UserRoleStudent {
+		studentID = user.ID
+	} else {
+		if studentID == "" {
+			return nil, errors.New("student_id is required")
+		}
+		if err := s.access.check(ctx, user.ID, vacancy, entities.CompanyPermViewApplications); err != nil {
+			return nil, err
+		}
+	}
+
+	allowed, err := s.canStart(ctx, user, vacancy.ID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrConversationNotAllowed
+	}
+
+	conversation, err := s.repo.FindOrCreate(ctx, &entities.Conversation{
+		VacancyID:  vacancy.ID,
+		StudentID:  studentID,
+		EmployerID: vacancy.EmployerID,
+		CompanyID:  vacancy.CompanyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prepare(ctx, user, conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// GetConversations возвращает переписки пользователя, последние активные первыми, и их общее число
+func (s *ConversationService) GetConversations(ctx context.Context, user *entities.User, limit, offset int) ([]*entities.Conversation, int64, error) {
+	if limit <= 0 {
+		limit = defaultConversationPageLimit
+	}
+	if limit > maxConversationPageLimit {
+		limit = maxConversationPageLimit
+	}
+	if offset < 0 {
+		return nil, 0, errors.New("offset cannot be negative")
+	}
+
+	participant, err := s.participant(ctx, user)
+	if err != nil {
+		return nil, 0, err
+	}
+	conversations, err := s.repo.FindByParticipant(ctx, participant, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.CountByParticipant(ctx, participant)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.prepare(ctx, user, conversations...); err != nil {
+		return nil, 0, err
+	}
+	return conversations, total, nil
+}
+
+// UnreadCount возвращает число непрочитанных сообщений во всех переписках пользователя
+func (s *ConversationService) UnreadCount(ctx context.Context, user *entities.User) (int64, error) {
+	participant, err := s.participant(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.CountUnread(ctx, participant, userSide(user))
+}
+
+// GetConversation возвращает переписку, если пользователь в ней участвует
+func (s *ConversationService) GetConversation(ctx context.Context, user *entities.User, id string) (*entities.Conversation, error) {
+	conversation, _, err := s.getConversation(ctx, user, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.prepare(ctx, user, conversation); err != nil {
+		return nil, err
+	}
+	return conversation, nil
+}
+
+// GetMessages возвращает сообщения переписки, новые первыми; before — ID сообщения, раньше которого загружать
+func (s *ConversationService) GetMessages(ctx context.Context, user *entities.User, conversationID, before string, limit int) ([]*entities.Message, error) {
+	if limit <= 0 {
+		limit = defaultMessagePageLimit
+	}
+	if limit > maxMessagePageLimit {
+		limit = maxMessagePageLimit
+	}
+	if _, _, err := s.getConversation(ctx, user, conversationID); err != nil {
+		return nil, err
+	}
+	return s.messageRepo.FindByConversation(ctx, conversationID, before, limit)
+}
+
+// SendMessage отправляет сообщение в переписку и доставляет его подключенным участникам
+func (s *ConversationService) SendMessage(ctx context.Context, user *entities.User, conversationID string, message *entities.Message) error {
+	conversation, side, err := s.getConversation(ctx, user, conversationID)
+	if err != nil {
+		return err
+	}
+	if err := normalizeMessage(message); err != nil {
+		return err
+	}
+
+	message.ConversationID = conversation.ID
+	message.SenderID = user.ID
+	message.SenderSide = side
+	message.ReadAt = nil
+	message.EditedAt = nil
+	message.DeletedAt = nil
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		return err
+	}
+	if err := s.repo.AddMessage(ctx, conversation.ID, message, entities.OtherSide(side)); err != nil {
+		return err
+	}
+
+	s.broadcast(ctx, conversation, entities.EventMessageCreated, message)
+	return nil
+}
+
+// EditMessage заменяет текст сообщения; доступно только отправителю
+func (s *ConversationService) EditMessage(ctx context.Context, user *entities.User, conversationID, messageID, body string) (*entities.Message, error) {
+	conversation, message, err := s.getOwnMessage(ctx, user, conversationID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.DeletedAt != nil {
+		return nil, errors.New("deleted message cannot be edited")
+	}
+
+	message.Body = strings.TrimSpace(body)
+	if err := normalizeMessage(message); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	message.EditedAt = &now
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return nil, err
+	}
+	if err := s.refreshLastMessage(ctx, conversation, message); err != nil {
+		return nil, err
+	}
+
+	s.broadcast(ctx, conversation, entities.EventMessageUpdated, message)
+	return message, nil
+}
+
+// DeleteMessage удаляет текст и вложения сообщения, оставляя его в ленте; доступно только отправителю
+func (s *ConversationService) DeleteMessage(ctx context.Context, user *entities.User, conversationID, messageID string) error {
+	conversation, message, err := s.getOwnMessage(ctx, user, conversationID, messageID)
+	if err != nil {
+		return err
+	}
+	if message.DeletedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	message.Body = ""
+	message.Attachments = []entities.MessageAttachment{}
+	message.DeletedAt = &now
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return err
+	}
+	// Непрочитанное удаленное сообщение больше не учитывается в счетчике получателя
+	if message.ReadAt == nil {
+		if err := s.repo.AdjustUnread(ctx, conversation.ID, entities.OtherSide(message.SenderSide), -1); err != nil {
+			return err
+		}
+	}
+	// В превью переписки вместо удаленного сообщения показывается предыдущее
+	if conversation.LastMessage != nil && conversation.LastMessage.ID == message.ID {
+		previous, err := s.messageRepo.FindLast(ctx, conversation.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetLastMessage(ctx, conversation.ID, previous); err != nil {
+			return err
+		}
+	}
+
+	s.broadcast(ctx, conversation, entities.EventMessageDeleted, message)
+	return nil
+}
+
+// MarkRead отмечает прочитанными сообщения другой стороны и возвращает их количество
+func (s *ConversationService) MarkRead(ctx context.Context, user *entities.User, conversationID string) (int64, error) {
+	conversation, side, err := s.getConversation(ctx, user, conversationID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	read, err := s.messageRepo.MarkRead(ctx, conversation.ID, entities.OtherSide(side), now)
+	if err != nil {
+		return 0, err
+	}
+	// Счетчик уменьшается на число прочитанных, а не обнуляется: сообщения,
+	// отправленные после MarkRead в хранилище сообщений, остаются непрочитанными
+	if read > 0 {
+		if err := s.repo.AdjustUnread(ctx, conversation.ID, side, -int(read)); err != nil {
+			return 0, err
+		}
+	}
+	if last := conversation.LastMessage; read > 0 && last != nil && last.SenderSide != side && last.ReadAt == nil {
+		last.ReadAt = &now
+		if err := s.repo.SetLastMessage(ctx, conversation.ID, last); err != nil {
+			return 0, err
+		}
+	}
+
+	if read > 0 {
+		s.broadcast(ctx, conversation, entities.EventMessagesRead, map[string]interface{}{
+			"conversation_id": conversation.ID,
+			"reader_id":       user.ID,
+			"reader_side":     side,
+			"read_at":         now,
+		})
+	}
+	return read, nil
+}
+
+// canStart проверяет, что студент откликнулся на вакансию, а для работодателя — что студент открыт для подбора
+func (s *ConversationService) canStart(ctx context.Context, user *entities.User, vacancyID, studentID string) (bool, error) {
+	application, err := s.applicationRepo.FindByVacancyAndStudent(ctx, vacancyID, studentID)
+	if err != nil {
+		return false, err
+	}
+	if application != nil {
+		return true, nil
+	}
+	if user.Role == entities.UserRoleStudent {
+		return false, nil
+	}
+
+	profile, err := s.profileRepo.FindByUserID(ctx, studentID)
+	if err != nil {
+		return false, err
+	}
+	return profile != nil && profile.Discoverable, nil
+}
+
+// getConversation загружает переписку и определяет сторону пользователя в ней.
+// Посторонним переписка не раскрывается: для них она не найдена.
+func (s *ConversationService) getConversation(ctx context.Context, user *entities.User, id string) (*entities.Conversation, string, error) {
+	conversation, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if conversation == nil {
+		return nil, "", repositories.ErrConversationNotFound
+	}
+	if conversation.StudentID == user.ID {
+		return conversation, entities.ConversationSideStudent, nil
+	}
+
+	err = s.access.check(ctx, user.ID, conversationVacancy(conversation), entities.CompanyPermViewApplications)
+	if errors.Is(err, ErrNotVacancyOwner) || errors.Is(err, ErrCompanyForbidden) {
+		return nil, "", repositories.ErrConversationNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return conversation, entities.ConversationSideEmployer, nil
+}
+
+// getOwnMessage загружает сообщение переписки и проверяет, что его отправил пользователь
+func (s *ConversationService) getOwnMessage(ctx context.Context, user *entities.User, conversationID, messageID string) (*entities.Conversation, *entities.Message, error) {
+	conversation, _, err := s.getConversation(ctx, user, conversationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	message, err := s.messageRepo.FindByID(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if message == nil || message.ConversationID != conversation.ID {
+		return nil, nil, repositories.ErrMessageNotFound
+	}
+	if message.SenderID != user.ID {
+		return nil, nil, ErrNotMessageSender
+	}
+	return conversation, message, nil
+}
+
+// refreshLastMessage обновляет превью переписки, если отредактировано ее последнее сообщение
+func (s *ConversationService) refreshLastMessage(ctx context.Context, conversation *entities.Conversation, message *entities.Message) error {
+	if conversation.LastMessage == nil || conversation.LastMessage.ID != message.ID {
+		return nil
+	}
+	return s.repo.SetLastMessage(ctx, conversation.ID, message)
+}
+
+// participant собирает фильтр переписок пользователя: студент видит свои переписки,
+// работодатель — по своим вакансиям без компании и по вакансиям компаний, где может просматривать отклики
+func (s *ConversationService) participant(ctx context.Context, user *entities.User) (repositories.ConversationParticipant, error) {
+	if user.Role == entities.UserRoleStudent {
+		return repositories.ConversationParticipant{StudentID: user.ID}, nil
+	}
+
+	companies, err := s.companyRepo.FindByMember(ctx, user.ID)
+	if err != nil {
+		return repositories.ConversationParticipant{}, err
+	}
+	participant := repositories.ConversationParticipant{EmployerID: user.ID}
+	for _, company := range companies {
+		if company.Can(user.ID, entities.CompanyPermViewApplications) {
+			participant.CompanyIDs = append(participant.CompanyIDs, company.ID)
+		}
+	}
+	return participant, nil
+}
+
+// prepare заполняет счетчик непрочитанных для пользователя и карточки вакансий
+func (s *ConversationService) prepare(ctx context.Context, user *entities.User, conversations ...*entities.Conversation) error {
+	ids := make([]string, len(conversations))
+	for i, conversation := range conversations {
+		ids[i] = conversation.VacancyID
+		conversation.UnreadCount = conversation.EmployerUnread
+		if conversation.StudentID == user.ID {
+			conversation.UnreadCount = conversation.StudentUnread
+		}
+	}
+
+	found, err := s.vacancyRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	vacancies := make([]*entities.Vacancy, 0, len(found))
+	for _, vacancy := range found {
+		vacancies = append(vacancies, vacancy)
+	}
+	if err := attachCompanies(ctx, s.companyRepo, vacancies...); err != nil {
+		return err
+	}
+
+	// У удаленной вакансии карточки нет, переписка остается доступной
+	for _, conversation := range conversations {
+		if vacancy, ok := found[conversation.VacancyID]; ok {
+			conversation.Vacancy = &entities.VacancyCard{
+				ID:      vacancy.ID,
+				Title:   vacancy.Title,
+				Status:  vacancy.Status,
+				Company: vacancy.Company,
+			}
+		}
+	}
+	return nil
+}
+
+// broadcast доставляет событие переписки подключенным клиентам студента и стороны работодателя
+func (s *ConversationService) broadcast(ctx context.Context, conversation *entities.Conversation, eventType string, data interface{}) {
+	if s.broker == nil {
+		return
+	}
+	recipients, err := s.access.recipients(ctx, conversationVacancy(conversation), entities.CompanyPermViewApplications)
+	if err != nil {
+		log.Printf("failed to resolve recipients for conversation %s: %v", conversation.ID, err)
+		return
+	}
+	recipients = append(recipients, conversation.StudentID)
+
+	events := make([]entities.Event, len(recipients))
+	for i, userID := range recipients {
+		events[i] = entities.Event{UserID: userID, Type: eventType, Data: data}
+	}
+	if err := s.broker.Publish(ctx, events...); err != nil {
+		log.Printf("failed to publish %s for conversation %s: %v", eventType, conversation.ID, err)
+	}
+}
+
+// conversationVacancy вакансия с владельцами, зафиксированными при создании переписки, для проверки прав
+func conversationVacancy(conversation *entities.Conversation) *entities.Vacancy {
+	return &entities.Vacancy{
+		ID:         conversation.VacancyID,
+		EmployerID: conversation.EmployerID,
+		CompanyID:  conversation.CompanyID,
+	}
+}
+
+func userSide(user *entities.User) string {
+	if user.Role == entities.UserRoleStudent {
+		return entities.ConversationSideStudent
+	}
+	return entities.ConversationSideEmployer
+}
+
+// normalizeMessage проверяет текст и вложения сообщения; пустое сообщение без вложений не допускается
+func normalizeMessage(message *entities.Message) error {
+	message.Body = strings.TrimSpace(message.Body)
+	if utf8.RuneCountInString(message.Body) > maxMessageLength {
+		return fmt.Errorf("message must be at most %d characters", maxMessageLength)
+	}
+	if message.Attachments == nil {
+		message.Attachments = []entities.MessageAttachment{}
+	}
+	if len(message.Attachments) > maxMessageAttachments {
+		return fmt.Errorf("at most %d attachments are allowed", maxMessageAttachments)
+	}
+	for i := range message.Attachments {
+		attachment := &message.Attachments[i]
+		attachment.Name = strings.TrimSpace(attachment.Name)
+		if attachment.Name == "" {
+			return errors.New("attachment name is required")
+		}
+		if err := utils.ValidateHTTPURL(attachment.URL); err != nil {
+			return fmt.Errorf("attachment '%s' url %w", attachment.Name, err)
+		}
+		if attachment.Size < 0 {
+			return fmt.Errorf("attachment '%s' size cannot be negative", attachment.Name)
+		}
+	}
+	if message.Body == "" && len(message.Attachments) == 0 {
+		return errors.New("message body or attachments are required")
+	}
+	return nil
+}
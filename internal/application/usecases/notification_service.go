@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+)
+
+const (
+	defaultNotificationPageLimit = 20
+	maxNotificationPageLimit     = 100
+	// maxNotificationReplay сколько пропущенных уведомлений отдается клиенту при переподключении
+	maxNotificationReplay = 100
+)
+
+// NotificationService ведет почтовый ящик уведомлений, публикует в него события приложения
+// и доставляет их подключенным клиентам через брокер событий
+type NotificationService struct {
+	repo   repositories.NotificationRepository
+	broker services.EventBroker
+}
+
+func NewNotificationService(repo repositories.NotificationRepository, broker services.EventBroker) *NotificationService {
+	return &NotificationService{
+		repo:   repo,
+		broker: broker,
+	}
+}
+
+// Publish сохраняет уведомления в почтовых ящиках получателей и отправляет их подключенным клиентам.
+// Уведомления без получателя пропускаются.
+func (s *NotificationService) Publish(ctx context.Context, notifications ...*entities.Notification) error {
+	valid := make([]*entities.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		if notification == nil || notification.UserID == "" {
+			continue
+		}
+		notification.Read = false
+		notification.ReadAt = nil
+		valid = append(valid, notification)
+	}
+	if err := s.repo.CreateMany(ctx, valid); err != nil {
+		return err
+	}
+
+	if s.broker == nil {
+		return nil
+	}
+	events := make([]entities.Event, len(valid))
+	for i, notification := range valid {
+		events[i] = notificationEvent(notification)
+	}
+	return s.broker.Publish(ctx, events...)
+}
+
+// Subscribe подписывает клиента на уведомления пользователя до отмены ctx.
+// Если передан lastEventID, дополнительно возвращаются уведомления, созданные после него.
+// Подписка оформляется до чтения пропущенного, поэтому событие может прийти и в replay, и в канал.
+func (s *NotificationService) Subscribe(ctx context.Context, userID, lastEventID string) ([]entities.Event, <-chan entities.Event, error) {
+	if s.broker == nil {
+		return nil, nil, errors.New("real-time delivery is not configured")
+	}
+	events, err := s.broker.Subscribe(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lastEventID == "" {
+		return nil, events, nil
+	}
+
+	missed, err := s.repo.FindAfter(ctx, userID, lastEventID, maxNotificationReplay)
+	if err != nil {
+		return nil, nil, err
+	}
+	replay := make([]entities.Event, len(missed))
+	for i, notification := range missed {
+		replay[i] = notificationEvent(notification)
+	}
+	return replay, events, nil
+}
+
+// List возвращает страницу уведомлений пользователя, общее число уведомлений и число непрочитанных
+func (s *NotificationService) List(ctx context.Context, userID string, limit, offset int) ([]*entities.Notification, int64, int64, error) {
+	if limit <= 0 {
+		limit = defaultNotificationPageLimit
+	}
+	if limit > maxNotificationPageLimit {
+		limit = maxNotificationPageLimit
+	}
+	if offset < 0 {
+		return nil, 0, 0, errors.New("offset cannot be negative")
+	}
+
+	notifications, err := s.repo.FindByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	total, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return notifications, total, unread, nil
+}
+
+// UnreadCount возвращает число непрочитанных уведомлений пользователя
+func (s *NotificationService) UnreadCount(ctx context.Context, userID string) (int64, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead отмечает уведомление пользователя прочитанным
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) error {
+	return s.repo.MarkRead(ctx, userID, id)
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя и возвращает их количество
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	return s.repo.MarkAllRead(ctx, userID)
+}
+
+// Delete удаляет уведомление пользователя
+func (s *NotificationService) Delete(ctx context.Context, userID, id string) error {
+	return s.repo.Delete(ctx, userID, id)
+}
+
+// notificationEvent событие реального времени для уведомления; ID события совпадает с ID уведомления
+func notificationEvent(notification *entities.Notification) entities.Event {
+	return entities.Event{
+		ID:     notification.ID,
+		UserID: notification.UserID,
+		Type:   notification.Type,
+		Data:   notification,
+	}
+}
+
+// publishNotifications публикует уведомления о событии; сбой доставки не отменяет само действие
+func publishNotifications(ctx context.Context, publisher services.NotificationPublisher, notifications ...*entities.Notification) {
+	if publisher == nil || len(notifications) == 0 {
+		return
+	}
+	if err := publisher.Publish(ctx, notifications...); err != nil {
+		log.Printf("failed to publish %s notifications: %v", notifications[0].Type, err)
+	}
+}
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+// RateLimitError возвращается, когда запрос отклонен ограничением частоты.
+// RetryAfter сообщает, через сколько можно повторить запрос.
+type RateLimitError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %d seconds", e.Reason, int(e.RetryAfter.Round(time.Second).Seconds()))
+}
+
+// OTPLimitConfig ограничения на запрос и проверку одноразовых кодов
+type OTPLimitConfig struct {
+	ResendCooldown    time.Duration // минимальный интервал между запросами кода на один идентификатор
+	HourlyLimit       int           // запросов кода на идентификатор в час
+	DailyLimit        int           // запросов кода на идентификатор в сутки
+	IPHourlyLimit     int           // запросов кода с одного IP в час
+	MaxFailedAttempts int           // неверных вводов кода до блокировки
+	LockoutDuration   time.Duration // длительность блокировки проверки кода
+}
+
+func DefaultOTPLimitConfig() OTPLimitConfig {
+	return OTPLimitConfig{
+		ResendCooldown:    time.Minute,
+		HourlyLimit:       5,
+		DailyLimit:        10,
+		IPHourlyLimit:     30,
+		MaxFailedAttempts: maxOTPAttempts,
+		LockoutDuration:   15 * time.Minute,
+	}
+}
+
+type otpLimitCheck struct {
+	key    string
+	limit  int
+	window time.Duration
+	reason string
+}
+
+// OTPLimiter ограничивает частоту запросов кодов и блокирует подбор кода.
+// Счетчики неверных вводов не зависят от конкретного кода, поэтому повторный запрос кода не сбрасывает их.
+type OTPLimiter struct {
+	store  repositories.RateLimitRepository
+	config OTPLimitConfig
+	scope  string // префикс счетчиков; пустой у кодов входа и подтверждения контактов
+}
+
+func NewOTPLimiter(store repositories.RateLimitRepository, config OTPLimitConfig) *OTPLimiter {
+	return &OTPLimiter{store: store, config: config}
+}
+
+// Scoped возвращает ограничитель с теми же лимитами, но собственными счетчиками.
+// Нужен для кодов, которые отправляет другой пользователь (например, приглашения в команду),
+// чтобы они не расходовали квоту и не блокировали коды входа получателя.
+func (l *OTPLimiter) Scoped(scope string) *OTPLimiter {
+	return &OTPLimiter{store: l.store, config: l.config, scope: scope + ":"}
+}
+
+func (l *OTPLimiter) key(name, value string) string {
+	return "otp:" + l.scope + name + ":" + value
+}
+
+// AllowRequest проверяет лимиты на отправку кода и учитывает запрос
+func (l *OTPLimiter) AllowRequest(ctx context.Context, identifier, clientIP string) error {
+	if err := l.checkLocked(ctx, identifier); err != nil {
+		return err
+	}
+
+	// Лимит по IP проверяется первым: запросы с одного адреса расходуют его квоту, даже если их отклонит
+	// лимит идентификатора. Решение принимается по значению атомарного Hit, поэтому параллельные запросы
+	// не проходят проверку одновременно.
+	var checks []otpLimitCheck
+	if clientIP != "" {
+		checks = append(checks, otpLimitCheck{l.key("ip", clientIP), l.config.IPHourlyLimit, time.Hour, "too many code requests from this address"})
+	}
+	checks = append(checks,
+		otpLimitCheck{l.key("cooldown", identifier), 1, l.config.ResendCooldown, "code was requested recently"},
+		otpLimitCheck{l.key("hour", identifier), l.config.HourlyLimit, time.Hour, "too many code requests this hour"},
+		otpLimitCheck{l.key("day", identifier), l.config.DailyLimit, 24 * time.Hour, "too many code requests today"},
+	)
+
+	// Отклоненный запрос останавливается на первом превышенном лимите и не расходует следующие квоты
+	for _, check := range checks {
+		count, resetAt, err := l.store.Hit(ctx, check.key, check.window)
+		if err != nil {
+			return err
+		}
+		if count > check.limit {
+			return &RateLimitError{Reason: check.reason, RetryAfter: time.Until(resetAt)}
+		}
+	}
+	return nil
+}
+
+// CheckVerification возвращает ошибку, если проверка кода для идентификатора заблокирована
+func (l *OTPLimiter) CheckVerification(ctx context.Context, identifier string) error {
+	return l.checkLocked(ctx, identifier)
+}
+
+// RecordFailure учитывает неверный ввод кода и блокирует идентификатор при превышении лимита
+func (l *OTPLimiter) RecordFailure(ctx context.Context, identifier string) error {
+	count, _, err := l.store.Hit(ctx, l.key("fail", identifier), l.config.LockoutDuration)
+	if err != nil {
+		return err
+	}
+	if count < l.config.MaxFailedAttempts {
+		return nil
+	}
+
+	_, resetAt, err := l.store.Hit(ctx, l.key("lock", identifier), l.config.LockoutDuration)
+	if err != nil {
+		return err
+	}
+	l.store.Reset(ctx, l.key("fail", identifier))
+	return &RateLimitError{Reason: "too many failed attempts", RetryAfter: time.Until(resetAt)}
+}
+
+// RecordSuccess сбрасывает счетчик неверных вводов после успешной проверки
+func (l *OTPLimiter) RecordSuccess(ctx context.Context, identifier string) error {
+	return l.store.Reset(ctx, l.key("fail", identifier))
+}
+
+func (l *OTPLimiter) checkLocked(ctx context.Context, identifier string) error {
+	count, resetAt, err := l.store.Get(ctx, l.key("lock", identifier))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return &RateLimitError{Reason: "too many failed attempts", RetryAfter: time.Until(resetAt)}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	maxNameLength = 100
+
+	// Коды смены контакта привязаны к пользователю, чтобы два пользователя не перезаписывали коды друг друга
+	codeTypeChangeEmail = "change_email:"
+	codeTypeChangePhone = "change_phone:"
+	codeTypeSetPassword = "set_password:"
+)
+
+// ErrInvalidCurrentPassword возвращается, если при смене пароля указан неверный текущий пароль
+var ErrInvalidCurrentPassword = errors.New("current password is incorrect")
+
+// ErrPasswordAlreadySet возвращается при запросе кода установки пароля, если пароль уже есть
+var ErrPasswordAlreadySet = errors.New("password is already set, use current_password to change it")
+
+// ProfileUpdate изменяемые поля профиля; nil означает «не менять»
+type ProfileUpdate struct {
+	Name               *string
+	AvatarURL          *string
+	ContactPreferences *entities.ContactPreferences
+}
+
+// UpdateProfile изменяет имя, аватар и предпочтения связи пользователя
+func (a *AuthService) UpdateProfile(ctx context.Context, user *entities.User, update ProfileUpdate) (*entities.User, error) {
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if utf8.RuneCountInString(name) > maxNameLength {
+			return nil, fmt.Errorf("name must be at most %d characters", maxNameLength)
+		}
+		user.Name = name
+	}
+
+	if update.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*update.AvatarURL)
+		if avatarURL != "" {
+			if err := utils.ValidateHTTPURL(avatarURL); err != nil {
+				return nil, fmt.Errorf("avatar_url %w", err)
+			}
+		}
+		user.AvatarURL = avatarURL
+	}
+
+	if update.ContactPreferences != nil {
+		prefs := *update.ContactPreferences
+		if prefs.Email && user.Email == "" {
+			return nil, errors.New("cannot enable email notifications without an email")
+		}
+		if prefs.SMS && user.Phone == "" {
+			return nil, errors.New("cannot enable sms notifications without a phone")
+		}
+		user.ContactPreferences = prefs
+	}
+
+	if err := a.UserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ChangePassword меняет пароль после проверки текущего, завершает все сессии и выпускает новую пару токенов.
+// Пользователь, вошедший только по коду, вместо текущего пароля подтверждает установку кодом,
+// отправленным на его email или телефон (см. RequestPasswordSetCode): одного токена доступа недостаточно.
+func (a *AuthService) ChangePassword(ctx context.Context, user *entities.User, currentPassword, code, newPassword string) (*entities.TokenPair, error) {
+	if user.PasswordHash != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+			return nil, ErrInvalidCurrentPassword
+		}
+	} else {
+		if code == "" {
+			return nil, errors.New("code is required to set a password")
+		}
+		if err := a.checkChangeCode(ctx, passwordSetIdentifier(user), codeTypeSetPassword+user.ID, code); err != nil {
+			return nil, err
+		}
+	}
+	if err := utils.ValidatePassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := a.UserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	if err := a.RefreshRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	a.CodeRepo.DeleteCode(ctx, passwordSetIdentifier(user), codeTypeSetPassword+user.ID)
+
+	return a.issueTokens(ctx, user.ID, "")
+}
+
+// RequestPasswordSetCode отправляет код для установки первого пароля на email пользователя, а если его нет — на телефон
+func (a *AuthService) RequestPasswordSetCode(ctx context.Context, user *entities.User, clientIP string) error {
+	if user.PasswordHash != "" {
+		return ErrPasswordAlreadySet
+	}
+	identifier := passwordSetIdentifier(user)
+	if identifier == "" {
+		return errors.New("account has no email or phone to send the code to")
+	}
+
+	if err := a.Limiter.AllowRequest(ctx, identifier, clientIP); err != nil {
+		return err
+	}
+	code, err := a.storeChangeCode(ctx, identifier, codeTypeSetPassword+user.ID)
+	if err != nil {
+		return err
+	}
+
+	if identifier == user.Email {
+		return a.Email.SendEmail(ctx, identifier, "Установка пароля",
+			fmt.Sprintf("Код для установки пароля: %s\nКод действует %d минут.", code, int(otpExpiration.Minutes())))
+	}
+	return a.SMS.SendSMS(ctx, identifier, fmt.Sprintf("Код для установки пароля Student Job Finder: %s", code))
+}
+
+// RequestEmailChange отправляет код подтверждения на новый email
+func (a *AuthService) RequestEmailChange(ctx context.Context, user *entities.User, email, clientIP string) error {
+	if err := utils.ValidateEmail(email); err != nil {
+		return err
+	}
+	if email == user.Email {
+		return errors.New("new email matches the current one")
+	}
+	if existing, err := a.UserRepo.FindByEmail(ctx, email); err != nil {
+		return err
+	} else if existing != nil {
+		return repositories.ErrUserExists
+	}
+
+	if err := a.Limiter.AllowRequest(ctx, email, clientIP); err != nil {
+		return err
+	}
+
+	code, err := a.storeChangeCode(ctx, email, codeTypeChangeEmail+user.ID)
+	if err != nil {
+		return err
+	}
+
+	return a.Email.SendEmail(ctx, email, "Подтверждение нового email",
+		fmt.Sprintf("Код для смены email: %s\nКод действует %d минут.", code, int(otpExpiration.Minutes())))
+}
+
+// ConfirmEmailChange проверяет код и меняет email пользователя
+func (a *AuthService) ConfirmEmailChange(ctx context.Context, user *entities.User, email, code string) (*entities.User, error) {
+	if err := a.checkChangeCode(ctx, email, codeTypeChangeEmail+user.ID, code); err != nil {
+		return nil, err
+	}
+
+	user.Email = email
+	user.IsVerified = true
+	if err := a.UserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	a.CodeRepo.DeleteCode(ctx, email, codeTypeChangeEmail+user.ID)
+
+	return user, nil
+}
+
+// RequestPhoneChange отправляет код подтверждения на новый телефон
+func (a *AuthService) RequestPhoneChange(ctx context.Context, user *entities.User, phone, clientIP string) error {
+	if err := utils.ValidatePhone(phone); err != nil {
+		return err
+	}
+	if phone == user.Phone {
+		return errors.New("new phone matches the current one")
+	}
+	if existing, err := a.UserRepo.FindByPhone(ctx, phone); err != nil {
+		return err
+	} else if existing != nil {
+		return repositories.ErrUserExists
+	}
+
+	if err := a.Limiter.AllowRequest(ctx, phone, clientIP); err != nil {
+		return err
+	}
+
+	code, err := a.storeChangeCode(ctx, phone, codeTypeChangePhone+user.ID)
+	if err != nil {
+		return err
+	}
+
+	return a.SMS.SendSMS(ctx, phone, fmt.Sprintf("Код для смены телефона Student Job Finder: %s", code))
+}
+
+// ConfirmPhoneChange проверяет код и меняет телефон пользователя
+func (a *AuthService) ConfirmPhoneChange(ctx context.Context, user *entities.User, phone, code string) (*entities.User, error) {
+	if err := a.checkChangeCode(ctx, phone, codeTypeChangePhone+user.ID, code); err != nil {
+		return nil, err
+	}
+
+	user.Phone = phone
+	user.IsVerified = true
+	if err := a.UserRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	a.CodeRepo.DeleteCode(ctx, phone, codeTypeChangePhone+user.ID)
+
+	return user, nil
+}
+
+// passwordSetIdentifier контакт, на который отправляется код установки пароля
+func passwordSetIdentifier(user *entities.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.Phone
+}
+
+// storeChangeCode генерирует и сохраняет код подтверждения для смены контакта или установки пароля
+func (a *AuthService) storeChangeCode(ctx context.Context, identifier, codeType string) (string, error) {
+	code := utils.GenerateNumericCode(otpLength)
+	err := a.CodeRepo.SetCode(ctx, &entities.VerificationCode{
+		Identifier: identifier,
+		CodeHash:   utils.HashCode(identifier, code),
+		Type:       codeType,
+		ExpiresAt:  time.Now().Add(otpExpiration).Unix(),
+	})
+	return code, err
+}
+
+// checkChangeCode проверяет код подтверждения с учетом лимитов на неверные вводы
+func (a *AuthService) checkChangeCode(ctx context.Context, identifier, codeType, code string) error {
+	if len(code) != otpLength {
+		return errors.New("invalid code format")
+	}
+	if err := a.Limiter.CheckVerification(ctx, identifier); err != nil {
+		return err
+	}
+
+	vc, err := a.CodeRepo.GetCode(ctx, identifier, codeType)
+	if err != nil || vc == nil || time.Now().Unix() > vc.ExpiresAt {
+		return errors.New("invalid or expired code")
+	}
+	if vc.Attempts >= maxOTPAttempts {
+		return errors.New("maximum attempts exceeded")
+	}
+
+	if !utils.CheckCode(identifier, code, vc.CodeHash) {
+		a.CodeRepo.IncrementAttempts(ctx, identifier, codeType)
+		if err := a.Limiter.RecordFailure(ctx, identifier); err != nil {
+			return err
+		}
+		return errors.New("invalid or expired code")
+	}
+	a.Limiter.RecordSuccess(ctx, identifier)
+	return nil
+}
@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/matching"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+const (
+	// maxRecommendationCandidates сколько самых свежих активных вакансий оценивается при подборе рекомендаций
+	maxRecommendationCandidates = 500
+	// maxCandidateProfiles сколько недавно обновленных профилей оценивается при подборе кандидатов на вакансию
+	maxCandidateProfiles = 500
+)
+
+type RecommendationService struct {
+	profileRepo     repositories.StudentProfileRepository
+	vacancyRepo     repositories.VacancyRepository
+	applicationRepo repositories.ApplicationRepository
+	companyRepo     repositories.CompanyRepository
+	scorer          matching.Scorer
+	access          vacancyAccess
+}
+
+func NewRecommendationService(profileRepo repositories.StudentProfileRepository, vacancyRepo repositories.VacancyRepository,
+	applicationRepo repositories.ApplicationRepository, companyRepo repositories.CompanyRepository, scorer matching.Scorer) *RecommendationService {
+	return &RecommendationService{
+		profileRepo:     profileRepo,
+		vacancyRepo:     vacancyRepo,
+		applicationRepo: applicationRepo,
+		companyRepo:     companyRepo,
+		scorer:          scorer,
+		access:          vacancyAccess{companyRepo: companyRepo},
+	}
+}
+
+// RecommendVacancies ранжирует активные вакансии с непрошедшим дедлайном по соответствию профилю студента.
+// Вакансии, на которые студент уже откликнулся, не рекомендуются.
+func (s *RecommendationService) RecommendVacancies(ctx context.Context, studentID string, limit, offset int) ([]matching.VacancyMatch, int, error) {
+	if limit <= 0 {
+		limit = defaultVacancyPageLimit
+	}
+	if limit > maxVacancyPageLimit {
+		limit = maxVacancyPageLimit
+	}
+	if offset < 0 {
+		return nil, 0, errors.New("offset cannot be negative")
+	}
+
+	profile, err := s.profileRepo.FindByUserID(ctx, studentID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if profile == nil {
+		return nil, 0, repositories.ErrStudentProfileNotFound
+	}
+
+	applications, err := s.applicationRepo.FindByStudent(ctx, studentID)
+	if err != nil {
+		return nil, 0, err
+	}
+	applied := make(map[string]bool, len(applications))
+	for _, application := range applications {
+		applied[application.VacancyID] = true
+	}
+
+	candidates, err := s.activeVacancies(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	vacancies := make([]*entities.Vacancy, 0, len(candidates))
+	for _, vacancy := range candidates {
+		if !applied[vacancy.ID] {
+			vacancies = append(vacancies, vacancy)
+		}
+	}
+
+	ranked := s.scorer.RankVacancies(profile, vacancies, time.Now())
+	total := len(ranked)
+	if offset >= total {
+		return []matching.VacancyMatch{}, total, nil
+	}
+	page := ranked[offset:min(offset+limit, total)]
+
+	pageVacancies := make([]*entities.Vacancy, len(page))
+	for i, item := range page {
+		pageVacancies[i] = item.Vacancy
+	}
+	if err := attachCompanies(ctx, s.companyRepo, pageVacancies...); err != nil {
+		return nil, 0, err
+	}
+	return page, total, nil
+}
+
+// RecommendCandidates ранжирует студентов, согласившихся на подбор, как кандидатов на вакансию.
+// Доступно тем, кто может просматривать отклики на вакансию; уже откликнувшиеся студенты исключаются.
+func (s *RecommendationService) RecommendCandidates(ctx context.Context, userID, vacancyID string, limit, offset int) ([]matching.CandidateMatch, int, error) {
+	if limit <= 0 {
+		limit = defaultVacancyPageLimit
+	}
+	if limit > maxVacancyPageLimit {
+		limit = maxVacancyPageLimit
+	}
+	if offset < 0 {
+		return nil, 0, errors.New("offset cannot be negative")
+	}
+
+	vacancy, err := s.vacancyRepo.FindByID(ctx, vacancyID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if vacancy == nil {
+		return nil, 0, errors.New("vacancy not found")
+	}
+	if err := s.access.check(ctx, userID, vacancy, entities.CompanyPermViewApplications); err != nil {
+		return nil, 0, err
+	}
+
+	applications, err := s.applicationRepo.FindByVacancy(ctx, vacancy.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	applied := make(map[string]bool, len(applications))
+	for _, application := range applications {
+		applied[application.StudentID] = true
+	}
+
+	candidates, err := s.profileRepo.FindDiscoverable(ctx, vacancy.Skills, maxCandidateProfiles)
+	if err != nil {
+		return nil, 0, err
+	}
+	profiles := make([]*entities.StudentProfile, 0, len(candidates))
+	for _, profile := range candidates {
+		if !applied[profile.UserID] {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	ranked := s.scorer.RankCandidates(vacancy, profiles)
+	total := len(ranked)
+	if offset >= total {
+		return []matching.CandidateMatch{}, total, nil
+	}
+	return ranked[offset:min(offset+limit, total)], total, nil
+}
+
+// activeVacancies загружает до maxRecommendationCandidates самых свежих вакансий, доступных для отклика
+func (s *RecommendationService) activeVacancies(ctx context.Context) ([]*entities.Vacancy, error) {
+	filter := repositories.VacancyFilter{
+		Status:     entities.VacancyStatusActive,
+		NotExpired: true,
+	}
+	request := repositories.VacancyPageRequest{
+		Limit: maxVacancyPageLimit,
+		Sort:  repositories.VacancySortNewest,
+	}
+
+	var vacancies []*entities.Vacancy
+	for len(vacancies) < maxRecommendationCandidates {
+		page, err := s.vacancyRepo.FindAll(ctx, filter, request)
+		if err != nil {
+			return nil, err
+		}
+		vacancies = append(vacancies, page.Items...)
+		if page.NextCursor == "" || len(page.Items) == 0 {
+			break
+		}
+		request.Cursor = page.NextCursor
+	}
+	if len(vacancies) > maxRecommendationCandidates {
+		vacancies = vacancies[:maxRecommendationCandidates]
+	}
+	return vacancies, nil
+}
@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+const (
+	maxSavedSearches            = 20
+	maxSavedSearchNameLength    = 100
+	maxSavedSearchKeywordLength = 200
+	defaultSavedSearchName      = "Поиск вакансий"
+)
+
+// ErrTooManySavedSearches возвращается при превышении числа сохраненных поисков у студента
+var ErrTooManySavedSearches = fmt.Errorf("at most %d saved searches are allowed", maxSavedSearches)
+
+type SavedSearchService struct {
+	repo        repositories.SavedSearchRepository
+	vacancyRepo repositories.VacancyRepository
+	companyRepo repositories.CompanyRepository
+	notifier    services.AlertNotifier
+}
+
+func NewSavedSearchService(repo repositories.SavedSearchRepository, vacancyRepo repositories.VacancyRepository,
+	companyRepo repositories.CompanyRepository, notifier services.AlertNotifier) *SavedSearchService {
+	return &SavedSearchService{
+		repo:        repo,
+		vacancyRepo: vacancyRepo,
+		companyRepo: companyRepo,
+		notifier:    notifier,
+	}
+}
+
+// CreateSearch сохраняет фильтр вакансий студента
+func (s *SavedSearchService) CreateSearch(ctx context.Context, userID string, search *entities.SavedSearch) error {
+	if err := normalizeSavedSearch(search); err != nil {
+		return err
+	}
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= maxSavedSearches {
+		return ErrTooManySavedSearches
+	}
+
+	search.UserID = userID
+	search.PendingVacancyIDs = []string{}
+	return s.repo.Create(ctx, search)
+}
+
+// GetSearches возвращает сохраненные поиски студента
+func (s *SavedSearchService) GetSearches(ctx context.Context, userID string) ([]*entities.SavedSearch, error) {
+	return s.repo.FindByUser(ctx, userID)
+}
+
+// GetSearch возвращает сохраненный поиск, если он принадлежит студенту
+func (s *SavedSearchService) GetSearch(ctx context.Context, userID, id string) (*entities.SavedSearch, error) {
+	search, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if search == nil || search.UserID != userID {
+		return nil, repositories.ErrSavedSearchNotFound
+	}
+	return search, nil
+}
+
+// UpdateSearch заменяет фильтр и частоту оповещений сохраненного поиска
+func (s *SavedSearchService) UpdateSearch(ctx context.Context, userID string, search *entities.SavedSearch) error {
+	existing, err := s.GetSearch(ctx, userID, search.ID)
+	if err != nil {
+		return err
+	}
+	if err := normalizeSavedSearch(search); err != nil {
+		return err
+	}
+
+	search.UserID = existing.UserID
+	search.PendingVacancyIDs = existing.PendingVacancyIDs
+	search.LastNotifiedAt = existing.LastNotifiedAt
+	search.CreatedAt = existing.CreatedAt
+	return s.repo.Update(ctx, search)
+}
+
+// DeleteSearch удаляет сохраненный поиск студента
+func (s *SavedSearchService) DeleteSearch(ctx context.Context, userID, id string) error {
+	if _, err := s.GetSearch(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// MatchVacancy сопоставляет опубликованную вакансию с сохраненными поисками.
+// Мгновенные оповещения отправляются сразу, остальные вакансии копятся до следующей сводки.
+func (s *SavedSearchService) MatchVacancy(ctx context.Context, vacancy *entities.Vacancy) error {
+	now := time.Now()
+	if vacancy.Status != entities.VacancyStatusActive || vacancy.UnavailableReason(now) != "" {
+		return nil
+	}
+
+	candidates, err := s.repo.FindMatchCandidates(ctx, vacancy)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, search := range candidates {
+		if !savedSearchMatches(search, vacancy, now) {
+			continue
+		}
+		if search.Frequency != entities.AlertFrequencyInstant {
+			if err := s.repo.AddPending(ctx, search.ID, vacancy.ID); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := s.notify(ctx, search, []*entities.Vacancy{vacancy}, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SendDigests отправляет сводки поискам, у которых подошел срок, и возвращает число отправленных сводок.
+// Вакансии, которые за время ожидания закрылись или перестали подходить, в сводку не попадают.
+func (s *SavedSearchService) SendDigests(ctx context.Context) (int, error) {
+	now := time.Now()
+	sent := 0
+	var errs []error
+	for _, frequency := range []string{entities.AlertFrequencyDaily, entities.AlertFrequencyWeekly} {
+		period := (&entities.SavedSearch{Frequency: frequency}).DigestPeriod()
+		due, err := s.repo.FindDigestsDue(ctx, frequency, now.Add(-period))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, search := range due {
+			found, err := s.vacancyRepo.FindByIDs(ctx, search.PendingVacancyIDs)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			vacancies := make([]*entities.Vacancy, 0, len(found))
+			for _, id := range search.PendingVacancyIDs {
+				if vacancy, ok := found[id]; ok && savedSearchMatches(search, vacancy, now) {
+					vacancies = append(vacancies, vacancy)
+				}
+			}
+
+			if len(vacancies) == 0 {
+				err = s.repo.MarkNotified(ctx, search.ID, search.PendingVacancyIDs, search.LastNotifiedAt)
+			} else if err = s.notify(ctx, search, vacancies, now); err == nil {
+				sent++
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// RunDigests отправляет сводки сразу и затем каждые interval до отмены контекста
+func (s *SavedSearchService) RunDigests(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := s.SendDigests(ctx)
+		if err != nil {
+			log.Printf("saved search digests failed: %v", err)
+		} else if sent > 0 {
+			log.Printf("saved search digests: sent %d", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify доставляет оповещение и убирает отправленные вакансии из ожидающих
+func (s *SavedSearchService) notify(ctx context.Context, search *entities.SavedSearch, vacancies []*entities.Vacancy, now time.Time) error {
+	if err := attachCompanies(ctx, s.companyRepo, vacancies...); err != nil {
+		return err
+	}
+	if err := s.notifier.NotifyVacancyAlert(ctx, entities.VacancyAlert{Search: search, Vacancies: vacancies}); err != nil {
+		return err
+	}
+
+	ids := make([]string, len(vacancies))
+	for i, vacancy := range vacancies {
+		ids[i] = vacancy.ID
+	}
+	if search.Frequency != entities.AlertFrequencyInstant {
+		ids = search.PendingVacancyIDs
+	}
+	return s.repo.MarkNotified(ctx, search.ID, ids, now)
+}
+
+// savedSearchMatches проверяет вакансию на соответствие сохраненному поиску без обращения к хранилищу
+func savedSearchMatches(search *entities.SavedSearch, vacancy *entities.Vacancy, now time.Time) bool {
+	filter := repositories.VacancyFilter{
+		Status:     entities.VacancyStatusActive,
+		Type:       search.Type,
+		Format:     search.Format,
+		Location:   search.Location,
+		MinSalary:  search.MinSalary,
+		Skills:     search.Skills,
+		NotExpired: true,
+	}
+	if !filter.Matches(vacancy, now) {
+		return false
+	}
+	if search.Keyword == "" {
+		return true
+	}
+
+	// Ключевые слова сопоставляются так же, как в полнотекстовом поиске вакансий:
+	// по основам слов, достаточно совпадения хотя бы одного слова
+	terms := make(map[string]bool)
+	for _, term := range utils.StemTokens(strings.Join([]string{
+		vacancy.Title,
+		vacancy.Description,
+		strings.Join(vacancy.Skills, " "),
+		strings.Join(vacancy.Requirements, " "),
+		strings.Join(vacancy.Responsibilities, " "),
+	}, " ")) {
+		terms[term] = true
+	}
+	for _, term := range utils.StemTokens(search.Keyword) {
+		if terms[term] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeSavedSearch проверяет фильтр и частоту оповещений, обрезает пробелы и нормализует навыки
+func normalizeSavedSearch(search *entities.SavedSearch) error {
+	search.Name = strings.TrimSpace(search.Name)
+	search.Keyword = strings.Join(strings.Fields(search.Keyword), " ")
+	search.Location = strings.TrimSpace(search.Location)
+	search.Skills = entities.NormalizeSkills(search.Skills)
+
+	if search.Name == "" {
+		search.Name = search.Keyword
+	}
+	if search.Name == "" {
+		search.Name = defaultSavedSearchName
+	}
+	if utf8.RuneCountInString(search.Name) > maxSavedSearchNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxSavedSearchNameLength)
+	}
+	if utf8.RuneCountInString(search.Keyword) > maxSavedSearchKeywordLength {
+		return fmt.Errorf("keyword must be at most %d characters", maxSavedSearchKeywordLength)
+	}
+
+	if search.Type != "" &&
+		search.Type != entities.VacancyTypeFull &&
+		search.Type != entities.VacancyTypePartial &&
+		search.Type != entities.VacancyTypeInternship {
+		return errors.New("invalid type, must be 'Полная', 'Частичная', or 'Стажировка'")
+	}
+	if search.Format != "" &&
+		search.Format != entities.VacancyFormatOffice &&
+		search.Format != entities.VacancyFormatRemote &&
+		search.Format != entities.VacancyFormatHybrid {
+		return errors.New("invalid format, must be 'Офис', 'Удалённо', or 'Гибрид'")
+	}
+	if search.MinSalary != nil && *search.MinSalary < 0 {
+		return errors.New("min_salary cannot be negative")
+	}
+	if search.Keyword == "" && search.Type == "" && search.Format == "" && search.Location == "" &&
+		search.MinSalary == nil && len(search.Skills) == 0 {
+		return errors.New("saved search must have at least one criterion")
+	}
+
+	switch search.Frequency {
+	case "":
+		search.Frequency = entities.AlertFrequencyInstant
+	case entities.AlertFrequencyInstant, entities.AlertFrequencyDaily, entities.AlertFrequencyWeekly:
+	default:
+		return errors.New("invalid frequency, must be 'instant', 'daily' or 'weekly'")
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+const (
+	maxProfileTextLength   = 200
+	maxProfileDescLength   = 2000
+	maxProfileSkills       = 50
+	maxProfileListEntries  = 20
+	maxGPA                 = 4.0
+	minGraduationYear      = 1950
+	maxGraduationYearAhead = 10
+)
+
+type StudentProfileService struct {
+	repo            repositories.StudentProfileRepository
+	applicationRepo repositories.ApplicationRepository
+	vacancyRepo     repositories.VacancyRepository
+	access          vacancyAccess
+}
+
+func NewStudentProfileService(repo repositories.StudentProfileRepository, applicationRepo repositories.ApplicationRepository,
+	vacancyRepo repositories.VacancyRepository, companyRepo repositories.CompanyRepository) *StudentProfileService {
+	return &StudentProfileService{
+		repo:            repo,
+		applicationRepo: applicationRepo,
+		vacancyRepo:     vacancyRepo,
+		access:          vacancyAccess{companyRepo: companyRepo},
+	}
+}
+
+// GetProfile получает профиль студента
+func (s *StudentProfileService) GetProfile(ctx context.Context, userID string) (*entities.StudentProfile, error) {
+	profile, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, repositories.ErrStudentProfileNotFound
+	}
+	return profile, nil
+}
+
+// GetProfileForEmployer возвращает профиль студента работодателю, если студент открыт для подбора
+// или откликнулся на вакансию, отклики на которую работодатель вправе просматривать.
+// В остальных случаях профиль считается ненайденным.
+func (s *StudentProfileService) GetProfileForEmployer(ctx context.Context, employerID, studentID string) (*entities.StudentProfile, error) {
+	profile, err := s.GetProfile(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Discoverable {
+		return profile, nil
+	}
+
+	applications, err := s.applicationRepo.FindByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(applications))
+	for i, application := range applications {
+		ids[i] = application.VacancyID
+	}
+	vacancies, err := s.vacancyRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, vacancy := range vacancies {
+		err := s.access.check(ctx, employerID, vacancy, entities.CompanyPermViewApplications)
+		if err == nil {
+			return profile, nil
+		}
+		if !errors.Is(err, ErrNotVacancyOwner) && !errors.Is(err, ErrCompanyForbidden) && err.Error() != "company not found" {
+			return nil, err
+		}
+	}
+	return nil, repositories.ErrStudentProfileNotFound
+}
+
+// CreateProfile создает профиль текущего студента
+func (s *StudentProfileService) CreateProfile(ctx context.Context, userID string, profile *entities.StudentProfile) error {
+	profile.UserID = userID
+	if err := normalizeStudentProfile(profile); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, profile)
+}
+
+// UpdateProfile полностью заменяет профиль текущего студента
+func (s *StudentProfileService) UpdateProfile(ctx context.Context, userID string, profile *entities.StudentProfile) error {
+	existing, err := s.GetProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	profile.UserID = userID
+	profile.CreatedAt = existing.CreatedAt
+	if err := normalizeStudentProfile(profile); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, profile)
+}
+
+// DeleteProfile удаляет профиль текущего студента
+func (s *StudentProfileService) DeleteProfile(ctx context.Context, userID string) error {
+	return s.repo.Delete(ctx, userID)
+}
+
+// normalizeStudentProfile проверяет поля профиля, обрезает пробелы и приводит навыки к словарю вакансий
+func normalizeStudentProfile(p *entities.StudentProfile) error {
+	p.University = strings.TrimSpace(p.University)
+	p.Faculty = strings.TrimSpace(p.Faculty)
+	if p.University == "" {
+		return errors.New("university is required")
+	}
+	if utf8.RuneCountInString(p.University) > maxProfileTextLength || utf8.RuneCountInString(p.Faculty) > maxProfileTextLength {
+		return fmt.Errorf("university and faculty must be at most %d characters", maxProfileTextLength)
+	}
+
+	maxYear := time.Now().Year() + maxGraduationYearAhead
+	if p.GraduationYear < minGraduationYear || p.GraduationYear > maxYear {
+		return fmt.Errorf("graduation_year must be between %d and %d", minGraduationYear, maxYear)
+	}
+	if p.GPA != nil && (*p.GPA < 0 || *p.GPA > maxGPA) {
+		return fmt.Errorf("gpa must be between 0 and %.1f", maxGPA)
+	}
+	if p.DesiredSalary != nil && *p.DesiredSalary < 0 {
+		return errors.New("desired_salary cannot be negative")
+	}
+
+	p.Skills = entities.NormalizeSkills(p.Skills)
+	if len(p.Skills) > maxProfileSkills {
+		return fmt.Errorf("at most %d skills are allowed", maxProfileSkills)
+	}
+	if len(p.Languages) > maxProfileListEntries || len(p.Experience) > maxProfileListEntries || len(p.Projects) > maxProfileListEntries {
+		return fmt.Errorf("languages, experience and projects are limited to %d entries each", maxProfileListEntries)
+	}
+
+	if p.Languages == nil {
+		p.Languages = []entities.LanguageSkill{}
+	}
+	for i := range p.Languages {
+		lang := &p.Languages[i]
+		lang.Language = strings.TrimSpace(lang.Language)
+		if lang.Language == "" {
+			return errors.New("language name is required")
+		}
+		if !isValidLanguageLevel(lang.Level) {
+			return fmt.Errorf("invalid level for language '%s', must be A1-C2 or 'native'", lang.Language)
+		}
+	}
+
+	if p.Experience == nil {
+		p.Experience = []entities.WorkExperience{}
+	}
+	for i := range p.Experience {
+		exp := &p.Experience[i]
+		exp.Company = strings.TrimSpace(exp.Company)
+		exp.Position = strings.TrimSpace(exp.Position)
+		if exp.Company == "" || exp.Position == "" {
+			return errors.New("experience company and position are required")
+		}
+		if exp.StartDate.IsZero() {
+			return errors.New("experience start_date is required")
+		}
+		if exp.EndDate != nil && exp.EndDate.Before(exp.StartDate) {
+			return errors.New("experience end_date cannot be before start_date")
+		}
+		if utf8.RuneCountInString(exp.Description) > maxProfileDescLength {
+			return fmt.Errorf("experience description must be at most %d characters", maxProfileDescLength)
+		}
+	}
+
+	if p.Projects == nil {
+		p.Projects = []entities.Project{}
+	}
+	for i := range p.Projects {
+		project := &p.Projects[i]
+		project.Name = strings.TrimSpace(project.Name)
+		if project.Name == "" {
+			return errors.New("project name is required")
+		}
+		if utf8.RuneCountInString(project.Description) > maxProfileDescLength {
+			return fmt.Errorf("project description must be at most %d characters", maxProfileDescLength)
+		}
+		if project.URL != "" {
+			if err := utils.ValidateHTTPURL(project.URL); err != nil {
+				return fmt.Errorf("project '%s' url %w", project.Name, err)
+			}
+		}
+		project.Skills = entities.NormalizeSkills(project.Skills)
+	}
+
+	if p.PreferredTypes == nil {
+		p.PreferredTypes = []string{}
+	}
+	for _, t := range p.PreferredTypes {
+		if t != entities.VacancyTypeFull && t != entities.VacancyTypePartial && t != entities.VacancyTypeInternship {
+			return errors.New("invalid preferred_types, must be 'Полная', 'Частичная', or 'Стажировка'")
+		}
+	}
+	if p.PreferredFormats == nil {
+		p.PreferredFormats = []string{}
+	}
+	for _, f := range p.PreferredFormats {
+		if f != entities.VacancyFormatOffice && f != entities.VacancyFormatRemote && f != entities.VacancyFormatHybrid {
+			return errors.New("invalid preferred_formats, must be 'Офис', 'Удалённо', or 'Гибрид'")
+		}
+	}
+
+	locations := make([]string, 0, len(p.PreferredLocations))
+	seen := make(map[string]bool, len(p.PreferredLocations))
+	for _, location := range p.PreferredLocations {
+		location = strings.TrimSpace(location)
+		key := strings.ToLower(location)
+		if location == "" || seen[key] {
+			continue
+		}
+		if utf8.RuneCountInString(location) > maxProfileTextLength {
+			return fmt.Errorf("preferred location must be at most %d characters", maxProfileTextLength)
+		}
+		seen[key] = true
+		locations = append(locations, location)
+	}
+	if len(locations) > maxProfileListEntries {
+		return fmt.Errorf("at most %d preferred locations are allowed", maxProfileListEntries)
+	}
+	p.PreferredLocations = locations
+
+	return nil
+}
+
+func isValidLanguageLevel(level string) bool {
+	switch level {
+	case entities.LanguageLevelA1, entities.LanguageLevelA2,
+		entities.LanguageLevelB1, entities.LanguageLevelB2,
+		entities.LanguageLevelC1, entities.LanguageLevelC2,
+		entities.LanguageLevelNative:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+// UnverifiedUserCleaner периодически удаляет неподтвержденных пользователей без пароля,
+// оставшихся от старого процесса входа по коду, который создавал пользователя до проверки кода.
+type UnverifiedUserCleaner struct {
+	userRepo repositories.UserRepository
+	maxAge   time.Duration
+	interval time.Duration
+}
+
+func NewUnverifiedUserCleaner(userRepo repositories.UserRepository, maxAge, interval time.Duration) *UnverifiedUserCleaner {
+	return &UnverifiedUserCleaner{
+		userRepo: userRepo,
+		maxAge:   maxAge,
+		interval: interval,
+	}
+}
+
+// PurgeOnce удаляет неподтвержденных пользователей старше maxAge и возвращает их количество
+func (c *UnverifiedUserCleaner) PurgeOnce(ctx context.Context) (int64, error) {
+	return c.userRepo.DeleteUnverifiedBefore(ctx, time.Now().Add(-c.maxAge))
+}
+
+// Run выполняет очистку сразу и затем каждые interval до отмены контекста
+func (c *UnverifiedUserCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := c.PurgeOnce(ctx)
+		if err != nil {
+			log.Printf("unverified user cleanup failed: %v", err)
+		} else if deleted > 0 {
+			log.Printf("unverified user cleanup: removed %d users", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
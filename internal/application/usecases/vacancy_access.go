@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+// ErrCompanyForbidden возвращается, когда роли пользователя в команде компании недостаточно для действия
+var ErrCompanyForbidden = errors.New("insufficient permissions in the company team")
+
+// vacancyAccess проверяет права пользователя на вакансию.
+// Вакансиями компании распоряжается команда согласно ролям, вакансией без компании — только ее автор.
+type vacancyAccess struct {
+	companyRepo repositories.CompanyRepository
+}
+
+func (a vacancyAccess) check(ctx context.Context, userID string, vacancy *entities.Vacancy, permission entities.CompanyPermission) error {
+	if vacancy.CompanyID == "" {
+		if vacancy.EmployerID == "" || vacancy.EmployerID != userID {
+			return ErrNotVacancyOwner
+		}
+		return nil
+	}
+	_, err := a.company(ctx, userID, vacancy.CompanyID, permission)
+	return err
+}
+
+// company загружает компанию и проверяет право пользователя в ее команде
+func (a vacancyAccess) company(ctx context.Context, userID, companyID string, permission entities.CompanyPermission) (*entities.Company, error) {
+	company, err := a.companyRepo.FindByID(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil {
+		return nil, errors.New("company not found")
+	}
+	if !company.Can(userID, permission) {
+		return nil, ErrCompanyForbidden
+	}
+	return company, nil
+}
+
+// recipients возвращает пользователей, которые вправе выполнять действие с вакансией
+func (a vacancyAccess) recipients(ctx context.Context, vacancy *entities.Vacancy, permission entities.CompanyPermission) ([]string, error) {
+	if vacancy.CompanyID == "" {
+		if vacancy.EmployerID == "" {
+			return nil, nil
+		}
+		return []string{vacancy.EmployerID}, nil
+	}
+
+	company, err := a.companyRepo.FindByID(ctx, vacancy.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if company == nil {
+		return nil, nil
+	}
+	var userIDs []string
+	for _, member := range company.Members {
+		if entities.CompanyRoleCan(member.Role, permission) {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	return userIDs, nil
+}
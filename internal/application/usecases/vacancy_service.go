@@ -3,23 +3,60 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
 	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
 )
 
+const (
+	defaultVacancyPageLimit = 20
+	maxVacancyPageLimit     = 100
+)
+
+// ErrNotVacancyOwner возвращается, когда пользователь пытается изменить чужую вакансию
+var ErrNotVacancyOwner = errors.New("only the vacancy owner can modify it")
+
 type VacancyService struct {
-	repo repositories.VacancyRepository
+	repo            repositories.VacancyRepository
+	searcher        repositories.VacancySearcher
+	companyRepo     repositories.CompanyRepository
+	applicationRepo repositories.ApplicationRepository
+	bookmarkRepo    repositories.BookmarkRepository
+	alerts          *SavedSearchService
+	publisher       services.NotificationPublisher
+	access          vacancyAccess
 }
 
-func NewVacancyService(repo repositories.VacancyRepository) *VacancyService {
+func NewVacancyService(repo repositories.VacancyRepository, searcher repositories.VacancySearcher, companyRepo repositories.CompanyRepository,
+	applicationRepo repositories.ApplicationRepository, bookmarkRepo repositories.BookmarkRepository,
+	alerts *SavedSearchService, publisher services.NotificationPublisher) *VacancyService {
 	return &VacancyService{
-		repo: repo,
+		repo:            repo,
+		searcher:        searcher,
+		companyRepo:     companyRepo,
+		applicationRepo: applicationRepo,
+		bookmarkRepo:    bookmarkRepo,
+		alerts:          alerts,
+		publisher:       publisher,
+		access:          vacancyAccess{companyRepo: companyRepo},
 	}
 }
 
-// CreateVacancy создает новую вакансию с валидацией
-func (s *VacancyService) CreateVacancy(ctx context.Context, vacancy *entities.Vacancy) error {
+// CreateVacancy создает новую вакансию с валидацией и привязывает ее к работодателю
+func (s *VacancyService) CreateVacancy(ctx context.Context, employerID string, vacancy *entities.Vacancy) error {
+	if employerID == "" {
+		return errors.New("employer_id is required")
+	}
+	vacancy.EmployerID = employerID
+	if err := s.checkCompany(ctx, employerID, vacancy); err != nil {
+		return err
+	}
+
 	// Валидация обязательных полей
 	if vacancy.Title == "" {
 		return errors.New("title is required")
@@ -69,9 +106,8 @@ func (s *VacancyService) CreateVacancy(ctx context.Context, vacancy *entities.Va
 	if vacancy.Status == "" {
 		vacancy.Status = entities.VacancyStatusActive
 	}
-	if vacancy.Skills == nil {
-		vacancy.Skills = []string{}
-	}
+	// Навыки хранятся в общем с профилями студентов словаре
+	vacancy.Skills = entities.NormalizeSkills(vacancy.Skills)
 	if vacancy.Responsibilities == nil {
 		vacancy.Responsibilities = []string{}
 	}
@@ -82,7 +118,14 @@ func (s *VacancyService) CreateVacancy(ctx context.Context, vacancy *entities.Va
 		vacancy.Benefits = []string{}
 	}
 
-	return s.repo.Create(ctx, vacancy)
+	if err := s.repo.Create(ctx, vacancy); err != nil {
+		return err
+	}
+	if err := attachCompanies(ctx, s.companyRepo, vacancy); err != nil {
+		return err
+	}
+	s.publish(ctx, vacancy)
+	return nil
 }
 
 // GetVacancy получает вакансию по ID
@@ -94,32 +137,149 @@ func (s *VacancyService) GetVacancy(ctx context.Context, id string) (*entities.V
 	if vacancy == nil {
 		return nil, errors.New("vacancy not found")
 	}
+	if err := attachCompanies(ctx, s.companyRepo, vacancy); err != nil {
+		return nil, err
+	}
 	return vacancy, nil
 }
 
-// GetAllVacancies получает все вакансии с фильтрацией по статусу
-func (s *VacancyService) GetAllVacancies(ctx context.Context, status string) ([]*entities.Vacancy, error) {
-	// Валидация статуса, если он указан
-	if status != "" {
-		if status != entities.VacancyStatusActive &&
-			status != entities.VacancyStatusPaused &&
-			status != entities.VacancyStatusClosed {
+// GetAllVacancies получает страницу вакансий с фильтрацией и сортировкой
+func (s *VacancyService) GetAllVacancies(ctx context.Context, filter repositories.VacancyFilter, page repositories.VacancyPageRequest) (*repositories.VacancyPage, error) {
+	// Валидация фильтров, если они указаны
+	if filter.Status != "" {
+		if filter.Status != entities.VacancyStatusActive &&
+			filter.Status != entities.VacancyStatusPaused &&
+			filter.Status != entities.VacancyStatusClosed {
 			return nil, errors.New("invalid status filter")
 		}
 	}
+	if filter.Type != "" {
+		if filter.Type != entities.VacancyTypeFull &&
+			filter.Type != entities.VacancyTypePartial &&
+			filter.Type != entities.VacancyTypeInternship {
+			return nil, errors.New("invalid type filter")
+		}
+	}
+	if filter.Format != "" {
+		if filter.Format != entities.VacancyFormatOffice &&
+			filter.Format != entities.VacancyFormatRemote &&
+			filter.Format != entities.VacancyFormatHybrid {
+			return nil, errors.New("invalid format filter")
+		}
+	}
+	if filter.MinSalary != nil && *filter.MinSalary < 0 {
+		return nil, errors.New("min_salary cannot be negative")
+	}
+
+	// Валидация параметров пагинации
+	if page.Limit <= 0 {
+		page.Limit = defaultVacancyPageLimit
+	}
+	if page.Limit > maxVacancyPageLimit {
+		page.Limit = maxVacancyPageLimit
+	}
+	if page.Offset < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+
+	switch page.Sort {
+	case "":
+		page.Sort = repositories.VacancySortNewest
+	case repositories.VacancySortNewest,
+		repositories.VacancySortDeadline,
+		repositories.VacancySortSalary,
+		repositories.VacancySortViews:
+	default:
+		return nil, errors.New("invalid sort, must be 'newest', 'deadline', 'salary' or 'views'")
+	}
+
+	result, err := s.repo.FindAll(ctx, filter, page)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachCompanies(ctx, s.companyRepo, result.Items...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SearchVacancies выполняет полнотекстовый поиск вакансий с ранжированием по релевантности.
+// Если статус не указан, ищутся только активные вакансии.
+func (s *VacancyService) SearchVacancies(ctx context.Context, query string, filter repositories.VacancyFilter, limit, offset int) (*repositories.VacancySearchPage, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) < 2 {
+		return nil, errors.New("search query must be at least 2 characters")
+	}
+	if filter.Status == "" {
+		filter.Status = entities.VacancyStatusActive
+	}
 
-	return s.repo.FindAll(ctx, status)
+	if limit <= 0 {
+		limit = defaultVacancyPageLimit
+	}
+	if limit > maxVacancyPageLimit {
+		limit = maxVacancyPageLimit
+	}
+	if offset < 0 {
+		return nil, errors.New("offset cannot be negative")
+	}
+
+	result, err := s.searcher.Search(ctx, query, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	vacancies := make([]*entities.Vacancy, len(result.Items))
+	for i, item := range result.Items {
+		vacancies[i] = item.Vacancy
+	}
+	if err := attachCompanies(ctx, s.companyRepo, vacancies...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetEmployerVacancies получает вакансии работодателя и вакансии компаний, в команде которых он состоит
+func (s *VacancyService) GetEmployerVacancies(ctx context.Context, employerID string) ([]*entities.Vacancy, error) {
+	companies, err := s.companyRepo.FindByMember(ctx, employerID)
+	if err != nil {
+		return nil, err
+	}
+	companyIDs := make([]string, len(companies))
+	for i, company := range companies {
+		companyIDs[i] = company.ID
+	}
+
+	vacancies, err := s.repo.FindByEmployer(ctx, employerID, companyIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachCompanies(ctx, s.companyRepo, vacancies...); err != nil {
+		return nil, err
+	}
+	return vacancies, nil
 }
 
 // UpdateVacancy обновляет существующую вакансию
-func (s *VacancyService) UpdateVacancy(ctx context.Context, vacancy *entities.Vacancy) error {
-	// Проверка существования вакансии
-	existing, err := s.repo.FindByID(ctx, vacancy.ID)
+func (s *VacancyService) UpdateVacancy(ctx context.Context, userID string, vacancy *entities.Vacancy) error {
+	// Проверка существования вакансии и прав на редактирование
+	existing, err := s.getManagedVacancy(ctx, userID, vacancy.ID, entities.CompanyPermEditVacancy)
 	if err != nil {
 		return err
 	}
-	if existing == nil {
-		return errors.New("vacancy not found")
+	vacancy.EmployerID = existing.EmployerID
+	// Статус меняется только через UpdateVacancyStatus, который запускает оповещения и уведомления
+	vacancy.Status = existing.Status
+	if vacancy.CompanyID != existing.CompanyID {
+		// Вывести вакансию из компании может только тот, кто управляет компанией,
+		// иначе участник команды забрал бы вакансию себе и сохранил доступ после исключения
+		if existing.CompanyID != "" {
+			if _, err := s.access.company(ctx, userID, existing.CompanyID, entities.CompanyPermManageCompany); err != nil {
+				return err
+			}
+		}
+		if err := s.checkCompany(ctx, userID, vacancy); err != nil {
+			return err
+		}
 	}
 
 	// Валидация обязательных полей
@@ -167,11 +327,13 @@ func (s *VacancyService) UpdateVacancy(ctx context.Context, vacancy *entities.Va
 		return errors.New("invalid format")
 	}
 
+	vacancy.Skills = entities.NormalizeSkills(vacancy.Skills)
+
 	return s.repo.Update(ctx, vacancy)
 }
 
 // UpdateVacancyStatus обновляет статус вакансии
-func (s *VacancyService) UpdateVacancyStatus(ctx context.Context, id string, status string) error {
+func (s *VacancyService) UpdateVacancyStatus(ctx context.Context, userID, id string, status string) error {
 	// Валидация статуса
 	if status != entities.VacancyStatusActive &&
 		status != entities.VacancyStatusPaused &&
@@ -179,28 +341,136 @@ func (s *VacancyService) UpdateVacancyStatus(ctx context.Context, id string, sta
 		return errors.New("invalid status, must be 'Активна', 'Приостановлена', or 'Закрыта'")
 	}
 
-	// Проверка существования вакансии
-	vacancy, err := s.repo.FindByID(ctx, id)
+	// Проверка существования вакансии и прав на смену статуса
+	vacancy, err := s.getManagedVacancy(ctx, userID, id, entities.CompanyPermChangeStatus)
 	if err != nil {
 		return err
 	}
-	if vacancy == nil {
-		return errors.New("vacancy not found")
+
+	if err := s.repo.UpdateStatus(ctx, id, status); err != nil {
+		return err
 	}
 
-	return s.repo.UpdateStatus(ctx, id, status)
+	// Снова активированная вакансия сопоставляется с сохраненными поисками, как новая
+	if status == entities.VacancyStatusActive && vacancy.Status != entities.VacancyStatusActive {
+		vacancy.Status = status
+		s.publish(ctx, vacancy)
+	}
+	if status == entities.VacancyStatusClosed && vacancy.Status != entities.VacancyStatusClosed {
+		closed := *vacancy
+		go s.notifyVacancyClosed(context.WithoutCancel(ctx), &closed)
+	}
+	return nil
 }
 
 // DeleteVacancy удаляет вакансию
-func (s *VacancyService) DeleteVacancy(ctx context.Context, id string) error {
-	// Проверка существования вакансии
+func (s *VacancyService) DeleteVacancy(ctx context.Context, userID, id string) error {
+	// Проверка существования вакансии и прав на удаление
+	if _, err := s.getManagedVacancy(ctx, userID, id, entities.CompanyPermDeleteVacancy); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// publish сопоставляет вакансию с сохраненными поисками в фоне, не задерживая ответ работодателю
+func (s *VacancyService) publish(ctx context.Context, vacancy *entities.Vacancy) {
+	if s.alerts == nil {
+		return
+	}
+	published := *vacancy
+	go func() {
+		if err := s.alerts.MatchVacancy(context.WithoutCancel(ctx), &published); err != nil {
+			log.Printf("saved search matching failed for vacancy %s: %v", published.ID, err)
+		}
+	}()
+}
+
+// notifyVacancyClosed уведомляет о закрытии вакансии студентов с незавершенными откликами и сохранивших ее
+func (s *VacancyService) notifyVacancyClosed(ctx context.Context, vacancy *entities.Vacancy) {
+	recipients := map[string]bool{}
+	if s.applicationRepo != nil {
+		applications, err := s.applicationRepo.FindByVacancy(ctx, vacancy.ID)
+		if err != nil {
+			log.Printf("failed to load applications for closed vacancy %s: %v", vacancy.ID, err)
+		}
+		for _, application := range applications {
+			if application.Status != entities.ApplicationStatusHired && application.Status != entities.ApplicationStatusRejected {
+				recipients[application.StudentID] = true
+			}
+		}
+	}
+	if s.bookmarkRepo != nil {
+		userIDs, err := s.bookmarkRepo.FindUserIDsByVacancy(ctx, vacancy.ID)
+		if err != nil {
+			log.Printf("failed to load bookmarks for closed vacancy %s: %v", vacancy.ID, err)
+		}
+		for _, userID := range userIDs {
+			recipients[userID] = true
+		}
+	}
+
+	notifications := make([]*entities.Notification, 0, len(recipients))
+	for userID := range recipients {
+		notifications = append(notifications, &entities.Notification{
+			UserID: userID,
+			Type:   entities.NotificationTypeVacancyClosed,
+			Title:  fmt.Sprintf("Вакансия «%s» закрыта", vacancy.Title),
+			Body:   "Работодатель больше не принимает отклики на эту вакансию.",
+			Data: map[string]interface{}{
+				"vacancy_id": vacancy.ID,
+			},
+		})
+	}
+	publishNotifications(ctx, s.publisher, notifications...)
+}
+
+// getManagedVacancy загружает вакансию и проверяет право пользователя на действие с ней
+func (s *VacancyService) getManagedVacancy(ctx context.Context, userID, id string, permission entities.CompanyPermission) (*entities.Vacancy, error) {
 	vacancy, err := s.repo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if vacancy == nil {
-		return errors.New("vacancy not found")
+		return nil, errors.New("vacancy not found")
+	}
+	if err := s.access.check(ctx, userID, vacancy, permission); err != nil {
+		return nil, err
+	}
+	return vacancy, nil
+}
+
+// checkCompany проверяет, что пользователь может публиковать вакансии от имени компании вакансии
+func (s *VacancyService) checkCompany(ctx context.Context, userID string, vacancy *entities.Vacancy) error {
+	if vacancy.CompanyID == "" {
+		return nil
 	}
+	_, err := s.access.company(ctx, userID, vacancy.CompanyID, entities.CompanyPermCreateVacancy)
+	return err
+}
 
-	return s.repo.Delete(ctx, id)
+// attachCompanies заполняет карточки компаний у вакансий одним запросом к хранилищу компаний
+func attachCompanies(ctx context.Context, companyRepo repositories.CompanyRepository, vacancies ...*entities.Vacancy) error {
+	ids := make([]string, 0, len(vacancies))
+	seen := make(map[string]bool, len(vacancies))
+	for _, vacancy := range vacancies {
+		if vacancy.CompanyID != "" && !seen[vacancy.CompanyID] {
+			seen[vacancy.CompanyID] = true
+			ids = append(ids, vacancy.CompanyID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	companies, err := companyRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, vacancy := range vacancies {
+		if company, ok := companies[vacancy.CompanyID]; ok {
+			vacancy.Company = company.Card()
+		}
+	}
+	return nil
 }
@@ -0,0 +1,64 @@
+package entities
+
+import "time"
+
+// Application отклик студента на вакансию
+type Application struct {
+	ID          string                    `json:"id" bson:"_id,omitempty"`
+	VacancyID   string                    `json:"vacancy_id" bson:"vacancy_id"`
+	StudentID   string                    `json:"student_id" bson:"student_id"`
+	CoverLetter string                    `json:"cover_letter" bson:"cover_letter"`
+	Status      string                    `json:"status" bson:"status"`
+	History     []ApplicationStatusChange `json:"history" bson:"history"`
+	CreatedAt   time.Time                 `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at" bson:"updated_at"`
+}
+
+// ApplicationStatusChange запись о смене этапа отклика
+type ApplicationStatusChange struct {
+	From      string    `json:"from" bson:"from"`
+	To        string    `json:"to" bson:"to"`
+	ChangedBy string    `json:"changed_by" bson:"changed_by"`
+	ChangedAt time.Time `json:"changed_at" bson:"changed_at"`
+}
+
+// ApplicationStatus константы для этапов отклика
+const (
+	ApplicationStatusNew         = "new"
+	ApplicationStatusViewed      = "viewed"
+	ApplicationStatusShortlisted = "shortlisted"
+	ApplicationStatusInterview   = "interview"
+	ApplicationStatusOffer       = "offer"
+	ApplicationStatusHired       = "hired"
+	ApplicationStatusRejected    = "rejected"
+)
+
+// applicationTransitions допустимые переходы между этапами отклика.
+// Отклонить кандидата можно на любом незавершенном этапе.
+var applicationTransitions = map[string][]string{
+	ApplicationStatusNew:         {ApplicationStatusViewed, ApplicationStatusRejected},
+	ApplicationStatusViewed:      {ApplicationStatusShortlisted, ApplicationStatusRejected},
+	ApplicationStatusShortlisted: {ApplicationStatusInterview, ApplicationStatusRejected},
+	ApplicationStatusInterview:   {ApplicationStatusOffer, ApplicationStatusRejected},
+	ApplicationStatusOffer:       {ApplicationStatusHired, ApplicationStatusRejected},
+}
+
+// IsValidApplicationStatus проверяет, что статус является известным этапом отклика
+func IsValidApplicationStatus(status string) bool {
+	switch status {
+	case ApplicationStatusNew, ApplicationStatusViewed, ApplicationStatusShortlisted,
+		ApplicationStatusInterview, ApplicationStatusOffer, ApplicationStatusHired, ApplicationStatusRejected:
+		return true
+	}
+	return false
+}
+
+// CanTransitionApplication проверяет, разрешен ли переход между этапами отклика
+func CanTransitionApplication(from, to string) bool {
+	for _, allowed := range applicationTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// Bookmark сохраненная студентом вакансия
+type Bookmark struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	VacancyID string    `json:"vacancy_id" bson:"vacancy_id"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// SavedVacancy сохраненная вакансия в списке закладок
+type SavedVacancy struct {
+	Vacancy *Vacancy  `json:"vacancy"`
+	SavedAt time.Time `json:"saved_at"`
+	// Unavailable причина, по которой на вакансию больше нельзя откликнуться; пусто, если можно
+	Unavailable string `json:"unavailable,omitempty"`
+}
+
+// VacancyUnavailable константы причин недоступности сохраненной вакансии
+const (
+	VacancyUnavailableClosed  = "closed"
+	VacancyUnavailableExpired = "deadline_passed"
+)
@@ -0,0 +1,183 @@
+package entities
+
+import "time"
+
+// Company компания-работодатель и ее команда. Участники команды — пользователи с ролью employer,
+// их роль в команде определяет права на вакансии компании.
+type Company struct {
+	ID          string          `json:"id" bson:"_id,omitempty"`
+	Name        string          `json:"name" bson:"name"`
+	Description string          `json:"description" bson:"description"`
+	LogoURL     string          `json:"logo_url" bson:"logo_url"`
+	Website     string          `json:"website" bson:"website"`
+	Industry    string          `json:"industry" bson:"industry"`
+	Size        string          `json:"size" bson:"size"` // см. CompanySize
+	City        string          `json:"city" bson:"city"`
+	SocialLinks []SocialLink    `json:"social_links" bson:"social_links"`
+	Members     []CompanyMember `json:"members" bson:"members"`
+	CreatedAt   time.Time       `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" bson:"updated_at"`
+}
+
+// SocialLink ссылка на страницу компании в соцсети
+type SocialLink struct {
+	Network string `json:"network" bson:"network"` // например, "linkedin", "telegram", "instagram"
+	URL     string `json:"url" bson:"url"`
+}
+
+// CompanyCard краткая карточка компании для встраивания в вакансию
+type CompanyCard struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	LogoURL  string `json:"logo_url"`
+	Industry string `json:"industry"`
+	City     string `json:"city"`
+}
+
+// Card возвращает краткую карточку компании
+func (c *Company) Card() *CompanyCard {
+	return &CompanyCard{
+		ID:       c.ID,
+		Name:     c.Name,
+		LogoURL:  c.LogoURL,
+		Industry: c.Industry,
+		City:     c.City,
+	}
+}
+
+// CompanyProfile публичный профиль компании: все поля, кроме состава команды
+type CompanyProfile struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	LogoURL     string       `json:"logo_url"`
+	Website     string       `json:"website"`
+	Industry    string       `json:"industry"`
+	Size        string       `json:"size"`
+	City        string       `json:"city"`
+	SocialLinks []SocialLink `json:"social_links"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Profile возвращает публичный профиль компании
+func (c *Company) Profile() *CompanyProfile {
+	return &CompanyProfile{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		LogoURL:     c.LogoURL,
+		Website:     c.Website,
+		Industry:    c.Industry,
+		Size:        c.Size,
+		City:        c.City,
+		SocialLinks: c.SocialLinks,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// CompanyMember участник команды компании
+type CompanyMember struct {
+	UserID   string    `json:"user_id" bson:"user_id"`
+	Role     string    `json:"role" bson:"role"` // см. CompanyRole
+	JoinedAt time.Time `json:"joined_at" bson:"joined_at"`
+}
+
+// MemberRole возвращает роль пользователя в команде или пустую строку, если он не участник
+func (c *Company) MemberRole(userID string) string {
+	for _, member := range c.Members {
+		if member.UserID == userID {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+// Can проверяет, есть ли у пользователя право в команде компании
+func (c *Company) Can(userID string, permission CompanyPermission) bool {
+	return CompanyRoleCan(c.MemberRole(userID), permission)
+}
+
+// CountRole возвращает количество участников с указанной ролью
+func (c *Company) CountRole(role string) int {
+	count := 0
+	for _, member := range c.Members {
+		if member.Role == role {
+			count++
+		}
+	}
+	return count
+}
+
+// CompanyInvitation приглашение в команду по email или телефону
+type CompanyInvitation struct {
+	CompanyID  string    `json:"company_id"`
+	Identifier string    `json:"identifier"` // email или телефон приглашенного
+	Role       string    `json:"role"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// CompanySize константы для размера компании
+const (
+	CompanySizeMicro  = "1-10"
+	CompanySizeSmall  = "11-50"
+	CompanySizeMedium = "51-200"
+	CompanySizeLarge  = "201-1000"
+	CompanySizeHuge   = "1000+"
+)
+
+// CompanyRole константы для ролей в команде компании
+const (
+	CompanyRoleOwner     = "owner"     // полный доступ, включая удаление компании и назначение владельцев
+	CompanyRoleAdmin     = "admin"     // управление профилем компании, командой и всеми вакансиями
+	CompanyRoleRecruiter = "recruiter" // создание и ведение вакансий, работа с откликами
+	CompanyRoleViewer    = "viewer"    // только просмотр вакансий и откликов
+)
+
+// CompanyPermission действие, которое может быть разрешено участнику команды
+type CompanyPermission string
+
+const (
+	CompanyPermManageCompany      CompanyPermission = "manage_company"      // изменять профиль компании и состав команды
+	CompanyPermCreateVacancy      CompanyPermission = "create_vacancy"      // публиковать вакансии от имени компании
+	CompanyPermEditVacancy        CompanyPermission = "edit_vacancy"        // редактировать вакансии компании
+	CompanyPermChangeStatus       CompanyPermission = "change_status"       // приостанавливать, закрывать и открывать вакансии
+	CompanyPermDeleteVacancy      CompanyPermission = "delete_vacancy"      // удалять вакансии компании
+	CompanyPermViewApplications   CompanyPermission = "view_applications"   // просматривать отклики
+	CompanyPermManageApplications CompanyPermission = "manage_applications" // переводить отклики по этапам
+)
+
+var companyRolePermissions = map[string][]CompanyPermission{
+	CompanyRoleOwner: {
+		CompanyPermManageCompany, CompanyPermCreateVacancy, CompanyPermEditVacancy, CompanyPermChangeStatus,
+		CompanyPermDeleteVacancy, CompanyPermViewApplications, CompanyPermManageApplications,
+	},
+	CompanyRoleAdmin: {
+		CompanyPermManageCompany, CompanyPermCreateVacancy, CompanyPermEditVacancy, CompanyPermChangeStatus,
+		CompanyPermDeleteVacancy, CompanyPermViewApplications, CompanyPermManageApplications,
+	},
+	CompanyRoleRecruiter: {
+		CompanyPermCreateVacancy, CompanyPermEditVacancy, CompanyPermChangeStatus,
+		CompanyPermViewApplications, CompanyPermManageApplications,
+	},
+	CompanyRoleViewer: {
+		CompanyPermViewApplications,
+	},
+}
+
+// IsValidCompanyRole проверяет, что роль в команде существует
+func IsValidCompanyRole(role string) bool {
+	_, ok := companyRolePermissions[role]
+	return ok
+}
+
+// CompanyRoleCan проверяет, разрешено ли действие роли в команде
+func CompanyRoleCan(role string, permission CompanyPermission) bool {
+	for _, p := range companyRolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package entities
+
+import "time"
+
+// Conversation переписка студента с работодателем по вакансии. На каждую пару (студент, вакансия) — одна переписка.
+// Со стороны работодателя в ней участвуют автор вакансии или команда ее компании.
+type Conversation struct {
+	ID            string    `json:"id" bson:"_id,omitempty"`
+	VacancyID     string    `json:"vacancy_id" bson:"vacancy_id"`
+	StudentID     string    `json:"student_id" bson:"student_id"`
+	EmployerID    string    `json:"employer_id" bson:"employer_id"`                   // автор вакансии
+	CompanyID     string    `json:"company_id,omitempty" bson:"company_id,omitempty"` // компания вакансии, если есть
+	LastMessage   *Message  `json:"last_message,omitempty" bson:"last_message,omitempty"`
+	LastMessageAt time.Time `json:"last_message_at" bson:"last_message_at"`
+	// StudentUnread и EmployerUnread число непрочитанных сообщений каждой стороны
+	StudentUnread  int `json:"-" bson:"student_unread"`
+	EmployerUnread int `json:"-" bson:"employer_unread"`
+	// UnreadCount число непрочитанных сообщений для текущего пользователя
+	UnreadCount int          `json:"unread_count" bson:"-"`
+	Vacancy     *VacancyCard `json:"vacancy,omitempty" bson:"-"`
+	CreatedAt   time.Time    `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" bson:"updated_at"`
+}
+
+// VacancyCard краткая карточка вакансии для списка переписок
+type VacancyCard struct {
+	ID      string       `json:"id"`
+	Title   string       `json:"title"`
+	Status  string       `json:"status"`
+	Company *CompanyCard `json:"company,omitempty"`
+}
+
+// Message сообщение в переписке. Удаленное сообщение остается в ленте без текста и вложений.
+type Message struct {
+	ID             string              `json:"id" bson:"_id,omitempty"`
+	ConversationID string              `json:"conversation_id" bson:"conversation_id"`
+	SenderID       string              `json:"sender_id" bson:"sender_id"`
+	SenderSide     string              `json:"sender_side" bson:"sender_side"` // см. ConversationSide
+	Body           string              `json:"body" bson:"body"`
+	Attachments    []MessageAttachment `json:"attachments" bson:"attachments"`
+	// ReadAt когда другая сторона прочитала сообщение
+	ReadAt    *time.Time `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty" bson:"edited_at,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+}
+
+// MessageAttachment ссылка на файл, загруженный во внешнее хранилище
+type MessageAttachment struct {
+	Name        string `json:"name" bson:"name"`
+	URL         string `json:"url" bson:"url"`
+	ContentType string `json:"content_type,omitempty" bson:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty" bson:"size,omitempty"`
+}
+
+// ConversationSide константы сторон переписки
+const (
+	ConversationSideStudent  = "student"
+	ConversationSideEmployer = "employer"
+)
+
+// Event типы событий переписки для клиентов в реальном времени
+const (
+	EventMessageCreated = "message_created"
+	EventMessageUpdated = "message_updated"
+	EventMessageDeleted = "message_deleted"
+	EventMessagesRead   = "messages_read"
+)
+
+// OtherSide возвращает противоположную сторону переписки
+func OtherSide(side string) string {
+	if side == ConversationSideStudent {
+		return ConversationSideEmployer
+	}
+	return ConversationSideStudent
+}
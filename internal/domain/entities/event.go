@@ -0,0 +1,9 @@
+package entities
+
+// Event событие для доставки подключенным клиентам пользователя в реальном времени
+type Event struct {
+	ID     string      `json:"id"`   // совпадает с ID уведомления, если событие можно воспроизвести из ящика
+	UserID string      `json:"-"`    // получатель
+	Type   string      `json:"type"` // например, тип уведомления
+	Data   interface{} `json:"data"`
+}
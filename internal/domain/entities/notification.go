@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// Notification уведомление во внутреннем почтовом ящике пользователя
+type Notification struct {
+	ID        string                 `json:"id" bson:"_id,omitempty"`
+	UserID    string                 `json:"user_id" bson:"user_id"`
+	Type      string                 `json:"type" bson:"type"`
+	Title     string                 `json:"title" bson:"title"`
+	Body      string                 `json:"body" bson:"body"`
+	Data      map[string]interface{} `json:"data,omitempty" bson:"data,omitempty"`
+	Read      bool                   `json:"read" bson:"read"`
+	ReadAt    *time.Time             `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt time.Time              `json:"created_at" bson:"created_at"`
+}
+
+// NotificationType константы типов уведомлений
+const (
+	NotificationTypeVacancyAlert             = "vacancy_alert"
+	NotificationTypeApplicationCreated       = "application_created"
+	NotificationTypeApplicationStatusChanged = "application_status_changed"
+	NotificationTypeVacancyClosed            = "vacancy_closed"
+)
@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// SavedSearch сохраненный студентом фильтр вакансий с оповещениями о новых подходящих вакансиях.
+// Пустые поля фильтра не ограничивают подбор.
+type SavedSearch struct {
+	ID        string   `json:"id" bson:"_id,omitempty"`
+	UserID    string   `json:"user_id" bson:"user_id"`
+	Name      string   `json:"name" bson:"name"`
+	Keyword   string   `json:"keyword" bson:"keyword"`
+	Type      string   `json:"type" bson:"type"`
+	Format    string   `json:"format" bson:"format"`
+	Location  string   `json:"location" bson:"location"`
+	MinSalary *int     `json:"min_salary,omitempty" bson:"min_salary,omitempty"`
+	Skills    []string `json:"skills" bson:"skills"`
+	Frequency string   `json:"frequency" bson:"frequency"` // "instant", "daily", "weekly"
+	// PendingVacancyIDs подошедшие вакансии, ожидающие следующей сводки
+	PendingVacancyIDs []string  `json:"-" bson:"pending_vacancy_ids"`
+	LastNotifiedAt    time.Time `json:"last_notified_at" bson:"last_notified_at"`
+	CreatedAt         time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// AlertFrequency константы частоты оповещений сохраненного поиска
+const (
+	AlertFrequencyInstant = "instant"
+	AlertFrequencyDaily   = "daily"
+	AlertFrequencyWeekly  = "weekly"
+)
+
+// DigestPeriod возвращает период сводки; 0 для мгновенных оповещений
+func (s *SavedSearch) DigestPeriod() time.Duration {
+	switch s.Frequency {
+	case AlertFrequencyDaily:
+		return 24 * time.Hour
+	case AlertFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// VacancyAlert оповещение о вакансиях, подошедших под сохраненный поиск
+type VacancyAlert struct {
+	Search    *SavedSearch
+	Vacancies []*Vacancy
+}
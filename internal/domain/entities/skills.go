@@ -0,0 +1,76 @@
+package entities
+
+import "strings"
+
+// skillAliases приводит распространенные варианты написания навыка к каноническому.
+// Ключ — написание в нижнем регистре.
+var skillAliases = map[string]string{
+	"go":         "Go",
+	"golang":     "Go",
+	"python":     "Python",
+	"java":       "Java",
+	"javascript": "JavaScript",
+	"js":         "JavaScript",
+	"typescript": "TypeScript",
+	"ts":         "TypeScript",
+	"c++":        "C++",
+	"cpp":        "C++",
+	"c#":         "C#",
+	"csharp":     "C#",
+	"php":        "PHP",
+	"kotlin":     "Kotlin",
+	"swift":      "Swift",
+	"sql":        "SQL",
+	"postgresql": "PostgreSQL",
+	"postgres":   "PostgreSQL",
+	"mysql":      "MySQL",
+	"mongodb":    "MongoDB",
+	"mongo":      "MongoDB",
+	"redis":      "Redis",
+	"docker":     "Docker",
+	"kubernetes": "Kubernetes",
+	"k8s":        "Kubernetes",
+	"git":        "Git",
+	"linux":      "Linux",
+	"react":      "React",
+	"reactjs":    "React",
+	"react.js":   "React",
+	"vue":        "Vue.js",
+	"vuejs":      "Vue.js",
+	"vue.js":     "Vue.js",
+	"angular":    "Angular",
+	"node.js":    "Node.js",
+	"nodejs":     "Node.js",
+	"node":       "Node.js",
+	"html":       "HTML",
+	"css":        "CSS",
+	"figma":      "Figma",
+	"excel":      "Excel",
+	"1с":         "1С",
+	"1c":         "1С",
+}
+
+// NormalizeSkill приводит навык к каноническому написанию, общему для вакансий и профилей студентов
+func NormalizeSkill(skill string) string {
+	skill = strings.Join(strings.Fields(skill), " ")
+	if canonical, ok := skillAliases[strings.ToLower(skill)]; ok {
+		return canonical
+	}
+	return skill
+}
+
+// NormalizeSkills нормализует список навыков, убирая пустые значения и дубликаты без учета регистра
+func NormalizeSkills(skills []string) []string {
+	result := make([]string, 0, len(skills))
+	seen := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skill = NormalizeSkill(skill)
+		key := strings.ToLower(skill)
+		if skill == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, skill)
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+// StudentProfile резюме студента. Один профиль на пользователя, ключом служит ID пользователя.
+type StudentProfile struct {
+	UserID             string           `json:"user_id" bson:"_id"`
+	University         string           `json:"university" bson:"university"`
+	Faculty            string           `json:"faculty" bson:"faculty"`
+	GraduationYear     int              `json:"graduation_year" bson:"graduation_year"`
+	GPA                *float64         `json:"gpa,omitempty" bson:"gpa,omitempty"` // по шкале 4.0
+	Skills             []string         `json:"skills" bson:"skills"`               // в словаре навыков вакансий, см. NormalizeSkills
+	Languages          []LanguageSkill  `json:"languages" bson:"languages"`
+	Experience         []WorkExperience `json:"experience" bson:"experience"`
+	Projects           []Project        `json:"projects" bson:"projects"`
+	DesiredSalary      *int             `json:"desired_salary,omitempty" bson:"desired_salary,omitempty"`
+	PreferredTypes     []string         `json:"preferred_types" bson:"preferred_types"`         // значения Vacancy.Type
+	PreferredFormats   []string         `json:"preferred_formats" bson:"preferred_formats"`     // значения Vacancy.Format
+	PreferredLocations []string         `json:"preferred_locations" bson:"preferred_locations"` // города, сравниваются с Vacancy.Location
+	Discoverable       bool             `json:"discoverable" bson:"discoverable"`               // согласие показываться работодателям в подборе кандидатов
+	CreatedAt          time.Time        `json:"created_at" bson:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at" bson:"updated_at"`
+}
+
+// LanguageSkill владение языком
+type LanguageSkill struct {
+	Language string `json:"language" bson:"language"`
+	Level    string `json:"level" bson:"level"` // "A1".."C2" или "native"
+}
+
+// WorkExperience место работы или стажировки
+type WorkExperience struct {
+	Company     string     `json:"company" bson:"company"`
+	Position    string     `json:"position" bson:"position"`
+	StartDate   time.Time  `json:"start_date" bson:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty" bson:"end_date,omitempty"` // nil — работает по сей день
+	Description string     `json:"description" bson:"description"`
+}
+
+// Project учебный или личный проект
+type Project struct {
+	Name        string   `json:"name" bson:"name"`
+	Description string   `json:"description" bson:"description"`
+	URL         string   `json:"url" bson:"url"`
+	Skills      []string `json:"skills" bson:"skills"`
+}
+
+// LanguageLevel константы уровней владения языком
+const (
+	LanguageLevelA1     = "A1"
+	LanguageLevelA2     = "A2"
+	LanguageLevelB1     = "B1"
+	LanguageLevelB2     = "B2"
+	LanguageLevelC1     = "C1"
+	LanguageLevelC2     = "C2"
+	LanguageLevelNative = "native"
+)
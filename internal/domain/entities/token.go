@@ -0,0 +1,24 @@
+package entities
+
+import "time"
+
+// TokenPair пара токенов, выдаваемая при входе и обновлении сессии
+type TokenPair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
+
+// RefreshToken серверная запись refresh-токена. Хранится только хеш токена.
+// Все токены одной сессии, полученные ротацией, имеют общий FamilyID.
+type RefreshToken struct {
+	ID         string     `bson:"_id,omitempty"`
+	UserID     string     `bson:"user_id"`
+	FamilyID   string     `bson:"family_id"`
+	TokenHash  string     `bson:"token_hash"`
+	ExpiresAt  time.Time  `bson:"expires_at"`
+	CreatedAt  time.Time  `bson:"created_at"`
+	RevokedAt  *time.Time `bson:"revoked_at"`
+	ReplacedBy string     `bson:"replaced_by,omitempty"`
+}
@@ -2,22 +2,47 @@ package entities
 
 import "time"
 
+// User единая модель пользователя для хранения и бизнес-логики.
+// Хеш пароля никогда не попадает в JSON.
 type User struct {
-    ID           string
-    Email        string
-    Phone        string
-    PasswordHash string
-    Name         string
-    Role         string
-    IsVerified   bool
-    CreatedAt    time.Time
+    ID                 string             `json:"id" bson:"_id"`
+    Email              string             `json:"email" bson:"email"`
+    Phone              string             `json:"phone" bson:"phone"`
+    PasswordHash       string             `json:"-" bson:"password_hash"`
+    Name               string             `json:"name" bson:"name"`
+    Role               string             `json:"role" bson:"role"`
+    IsVerified         bool               `json:"is_verified" bson:"is_verified"`
+    AvatarURL          string             `json:"avatar_url" bson:"avatar_url"`
+    ContactPreferences ContactPreferences `json:"contact_preferences" bson:"contact_preferences"`
+    CreatedAt          time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// ContactPreferences каналы, через которые пользователь согласен получать уведомления
+type ContactPreferences struct {
+    Email bool `json:"email" bson:"email"`
+    SMS   bool `json:"sms" bson:"sms"`
+}
+
+// DefaultContactPreferences включает каналы, для которых у пользователя указан контакт
+func DefaultContactPreferences(user *User) ContactPreferences {
+    return ContactPreferences{
+        Email: user.Email != "",
+        SMS:   user.Phone != "",
+    }
 }
 
 type VerificationCode struct {
     Identifier string // email or phone
-    Code       string
-    Type       string // "email" or "phone"
+    CodeHash   string // HMAC кода, см. utils.HashCode
+    Type       string // "email", "phone" or "reset"
     Attempts   int
     ExpiresAt  int64 // unix
+    // PendingRole роль, с которой пользователь будет создан после подтверждения кода
+    PendingRole string
 }
 
+// UserRole константы для ролей пользователей
+const (
+    UserRoleStudent  = "student"
+    UserRoleEmployer = "employer"
+)
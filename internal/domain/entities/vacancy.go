@@ -4,6 +4,12 @@ import "time"
 
 type Vacancy struct {
 	ID              string    `json:"id" bson:"_id,omitempty"`
+	EmployerID      string    `json:"employer_id" bson:"employer_id"`
+	CompanyID       string    `json:"company_id,omitempty" bson:"company_id,omitempty"`
+	// Company карточка компании, заполняется при выдаче и не хранится в вакансии
+	Company         *CompanyCard `json:"company,omitempty" bson:"-"`
+	// IsSaved есть ли вакансия в закладках текущего студента, заполняется только для студентов
+	IsSaved         *bool     `json:"is_saved,omitempty" bson:"-"`
 	Title           string    `json:"title" bson:"title"`
 	Type            string    `json:"type" bson:"type"` // "Полная", "Частичная", "Стажировка"
 	Format          string    `json:"format" bson:"format"` // "Офис", "Удалённо", "Гибрид"
@@ -25,6 +31,29 @@ type Vacancy struct {
 	UpdatedAt       time.Time `json:"updated_at" bson:"updated_at"`
 }
 
+// MaxSalary возвращает верхнюю границу зарплаты: фиксированную сумму или salary_to, 0 если зарплата не указана
+func (v *Vacancy) MaxSalary() int {
+	if v.SalaryFixed != nil {
+		return *v.SalaryFixed
+	}
+	if v.SalaryTo != nil {
+		return *v.SalaryTo
+	}
+	return 0
+}
+
+// UnavailableReason возвращает причину, по которой на вакансию нельзя откликнуться на момент now:
+// вакансия закрыта или ее дедлайн прошел. Пустая строка — вакансия доступна.
+func (v *Vacancy) UnavailableReason(now time.Time) string {
+	if v.Status == VacancyStatusClosed {
+		return VacancyUnavailableClosed
+	}
+	if !v.Deadline.IsZero() && v.Deadline.Before(now) {
+		return VacancyUnavailableExpired
+	}
+	return ""
+}
+
 // VacancyStatus константы для статусов вакансий
 const (
 	VacancyStatusActive    = "Активна"
@@ -0,0 +1,367 @@
+// Package matching оценивает соответствие вакансий и профилей студентов.
+// Оценка считается только по данным сущностей и не обращается к хранилищу.
+package matching
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// Factor константы факторов оценки
+const (
+	FactorSkills       = "skills"
+	FactorRequirements = "requirements"
+	FactorType         = "type"
+	FactorFormat       = "format"
+	FactorLocation     = "location"
+	FactorSalary       = "salary"
+	FactorFreshness    = "freshness"
+)
+
+// defaultFreshnessHalfLife срок, за который вклад свежести вакансии уменьшается вдвое
+const defaultFreshnessHalfLife = 14 * 24 * time.Hour
+
+// Weights относительные веса факторов. Факторы, для которых нет данных
+// (например, студент не указал желаемую зарплату), не учитываются, а веса остальных перераспределяются.
+type Weights struct {
+	Skills       float64
+	Requirements float64
+	Type         float64
+	Format       float64
+	Location     float64
+	Salary       float64
+	Freshness    float64
+}
+
+// DefaultWeights веса по умолчанию: совпадение навыков важнее остальных факторов
+var DefaultWeights = Weights{
+	Skills:       40,
+	Requirements: 10,
+	Type:         10,
+	Format:       10,
+	Location:     10,
+	Salary:       10,
+	Freshness:    10,
+}
+
+// Factor вклад одного фактора в итоговую оценку
+type Factor struct {
+	Name string `json:"name"`
+	// Score степень совпадения по фактору от 0 до 1
+	Score float64 `json:"score"`
+	// Contribution баллы, которые фактор добавил к итоговой оценке
+	Contribution float64  `json:"contribution"`
+	Reason       string   `json:"reason"`
+	Matched      []string `json:"matched,omitempty"`
+	Missing      []string `json:"missing,omitempty"`
+}
+
+// Match итоговая оценка от 0 до 100 и ее объяснение
+type Match struct {
+	Score   float64  `json:"score"`
+	Factors []Factor `json:"factors"`
+}
+
+// VacancyMatch вакансия и ее оценка для студента
+type VacancyMatch struct {
+	Vacancy *entities.Vacancy `json:"vacancy"`
+	Match
+}
+
+// CandidateMatch профиль студента и его оценка как кандидата на вакансию
+type CandidateMatch struct {
+	Profile *entities.StudentProfile `json:"profile"`
+	Match
+}
+
+type Scorer struct {
+	Weights           Weights
+	FreshnessHalfLife time.Duration
+}
+
+func NewScorer() Scorer {
+	return Scorer{
+		Weights:           DefaultWeights,
+		FreshnessHalfLife: defaultFreshnessHalfLife,
+	}
+}
+
+// ScoreVacancy оценивает, насколько вакансия подходит студенту на момент now
+func (s Scorer) ScoreVacancy(profile *entities.StudentProfile, vacancy *entities.Vacancy, now time.Time) Match {
+	skills := studentSkills(profile)
+	return combine(
+		weighted{s.Weights.Skills, scoreSkills(skills, vacancy)},
+		weighted{s.Weights.Requirements, scoreRequirements(skills, vacancy)},
+		weighted{s.Weights.Type, scoreType(profile, vacancy)},
+		weighted{s.Weights.Format, scoreFormat(profile, vacancy)},
+		weighted{s.Weights.Location, scoreLocation(profile, vacancy)},
+		weighted{s.Weights.Salary, scoreSalary(profile, vacancy)},
+		weighted{s.Weights.Freshness, s.scoreFreshness(vacancy, now)},
+	)
+}
+
+// ScoreCandidate оценивает студента как кандидата на вакансию по навыкам, типу занятости, формату и городу.
+// Зарплата и свежесть вакансии на выбор между кандидатами не влияют.
+func (s Scorer) ScoreCandidate(profile *entities.StudentProfile, vacancy *entities.Vacancy) Match {
+	skills := studentSkills(profile)
+	return combine(
+		weighted{s.Weights.Skills, scoreSkills(skills, vacancy)},
+		weighted{s.Weights.Type, scoreType(profile, vacancy)},
+		weighted{s.Weights.Format, scoreFormat(profile, vacancy)},
+		weighted{s.Weights.Location, scoreLocation(profile, vacancy)},
+	)
+}
+
+// weighted фактор и его вес; nil-фактор означает, что для него нет данных
+type weighted struct {
+	weight float64
+	factor *Factor
+}
+
+// combine нормирует веса применимых факторов и сводит их в оценку от 0 до 100
+func combine(items ...weighted) Match {
+	total := 0.0
+	for _, item := range items {
+		if item.factor != nil && item.weight > 0 {
+			total += item.weight
+		}
+	}
+
+	match := Match{Factors: []Factor{}}
+	if total == 0 {
+		return match
+	}
+	score := 0.0
+	for _, item := range items {
+		if item.factor == nil || item.weight <= 0 {
+			continue
+		}
+		factor := *item.factor
+		contribution := factor.Score * item.weight / total * 100
+		factor.Score = round(factor.Score, 2)
+		factor.Contribution = round(contribution, 1)
+		score += contribution
+		match.Factors = append(match.Factors, factor)
+	}
+	match.Score = round(score, 1)
+	return match
+}
+
+// RankVacancies оценивает вакансии и сортирует их по убыванию оценки, при равенстве — сначала новые
+func (s Scorer) RankVacancies(profile *entities.StudentProfile, vacancies []*entities.Vacancy, now time.Time) []VacancyMatch {
+	result := make([]VacancyMatch, len(vacancies))
+	for i, vacancy := range vacancies {
+		result[i] = VacancyMatch{Vacancy: vacancy, Match: s.ScoreVacancy(profile, vacancy, now)}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Vacancy.CreatedAt.After(result[j].Vacancy.CreatedAt)
+	})
+	return result
+}
+
+// RankCandidates оценивает студентов для вакансии и сортирует по убыванию оценки, при равенстве — сначала недавно обновившие профиль
+func (s Scorer) RankCandidates(vacancy *entities.Vacancy, profiles []*entities.StudentProfile) []CandidateMatch {
+	result := make([]CandidateMatch, len(profiles))
+	for i, profile := range profiles {
+		result[i] = CandidateMatch{Profile: profile, Match: s.ScoreCandidate(profile, vacancy)}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Profile.UpdatedAt.After(result[j].Profile.UpdatedAt)
+	})
+	return result
+}
+
+// studentSkills собирает навыки профиля и проектов; ключ — навык в нижнем регистре
+func studentSkills(profile *entities.StudentProfile) map[string]string {
+	skills := make(map[string]string, len(profile.Skills))
+	for _, skill := range profile.Skills {
+		skill = entities.NormalizeSkill(skill)
+		skills[strings.ToLower(skill)] = skill
+	}
+	for _, project := range profile.Projects {
+		for _, skill := range project.Skills {
+			skill = entities.NormalizeSkill(skill)
+			skills[strings.ToLower(skill)] = skill
+		}
+	}
+	delete(skills, "")
+	return skills
+}
+
+func scoreSkills(skills map[string]string, vacancy *entities.Vacancy) *Factor {
+	if len(vacancy.Skills) == 0 {
+		return nil
+	}
+	matched, missing := []string{}, []string{}
+	for _, skill := range vacancy.Skills {
+		if _, ok := skills[strings.ToLower(entities.NormalizeSkill(skill))]; ok {
+			matched = append(matched, skill)
+		} else {
+			missing = append(missing, skill)
+		}
+	}
+	return &Factor{
+		Name:    FactorSkills,
+		Score:   float64(len(matched)) / float64(len(vacancy.Skills)),
+		Reason:  fmt.Sprintf("%d of %d vacancy skills match the profile", len(matched), len(vacancy.Skills)),
+		Matched: matched,
+		Missing: missing,
+	}
+}
+
+// scoreRequirements считает долю требований вакансии, в тексте которых упоминаются навыки студента
+func scoreRequirements(skills map[string]string, vacancy *entities.Vacancy) *Factor {
+	if len(vacancy.Requirements) == 0 || len(skills) == 0 {
+		return nil
+	}
+	covered := 0
+	mentioned := make(map[string]bool)
+	var matched []string
+	for _, requirement := range vacancy.Requirements {
+		text := strings.ToLower(requirement)
+		hit := false
+		for key, skill := range skills {
+			if mentions(text, key) {
+				hit = true
+				if !mentioned[key] {
+					mentioned[key] = true
+					matched = append(matched, skill)
+				}
+			}
+		}
+		if hit {
+			covered++
+		}
+	}
+	sort.Strings(matched)
+	return &Factor{
+		Name:    FactorRequirements,
+		Score:   float64(covered) / float64(len(vacancy.Requirements)),
+		Reason:  fmt.Sprintf("%d of %d requirements mention profile skills", covered, len(vacancy.Requirements)),
+		Matched: matched,
+	}
+}
+
+func scoreType(profile *entities.StudentProfile, vacancy *entities.Vacancy) *Factor {
+	if len(profile.PreferredTypes) == 0 {
+		return nil
+	}
+	if contains(profile.PreferredTypes, vacancy.Type) {
+		return &Factor{Name: FactorType, Score: 1, Reason: fmt.Sprintf("type '%s' is preferred", vacancy.Type)}
+	}
+	return &Factor{Name: FactorType, Reason: fmt.Sprintf("type '%s' is not among preferred types", vacancy.Type)}
+}
+
+// scoreFormat засчитывает гибридный формат наполовину студентам, предпочитающим офис или удаленную работу
+func scoreFormat(profile *entities.StudentProfile, vacancy *entities.Vacancy) *Factor {
+	if len(profile.PreferredFormats) == 0 {
+		return nil
+	}
+	switch {
+	case contains(profile.PreferredFormats, vacancy.Format):
+		return &Factor{Name: FactorFormat, Score: 1, Reason: fmt.Sprintf("format '%s' is preferred", vacancy.Format)}
+	case vacancy.Format == entities.VacancyFormatHybrid:
+		return &Factor{Name: FactorFormat, Score: 0.5, Reason: "hybrid format partially matches preferred formats"}
+	default:
+		return &Factor{Name: FactorFormat, Reason: fmt.Sprintf("format '%s' is not among preferred formats", vacancy.Format)}
+	}
+}
+
+// scoreLocation считает удаленную вакансию подходящей для любого города
+func scoreLocation(profile *entities.StudentProfile, vacancy *entities.Vacancy) *Factor {
+	if len(profile.PreferredLocations) == 0 {
+		return nil
+	}
+	if vacancy.Format == entities.VacancyFormatRemote {
+		return &Factor{Name: FactorLocation, Score: 1, Reason: "remote work does not depend on location"}
+	}
+	for _, location := range profile.PreferredLocations {
+		if strings.EqualFold(strings.TrimSpace(location), strings.TrimSpace(vacancy.Location)) {
+			return &Factor{Name: FactorLocation, Score: 1, Reason: fmt.Sprintf("location '%s' is preferred", vacancy.Location), Matched: []string{vacancy.Location}}
+		}
+	}
+	return &Factor{Name: FactorLocation, Reason: fmt.Sprintf("location '%s' is not among preferred locations", vacancy.Location)}
+}
+
+// scoreSalary сравнивает верхнюю границу зарплаты с ожиданиями студента; недобор снижает оценку пропорционально
+func scoreSalary(profile *entities.StudentProfile, vacancy *entities.Vacancy) *Factor {
+	maxSalary := vacancy.MaxSalary()
+	if profile.DesiredSalary == nil || *profile.DesiredSalary <= 0 || maxSalary <= 0 {
+		return nil
+	}
+	desired := *profile.DesiredSalary
+	if maxSalary >= desired {
+		return &Factor{Name: FactorSalary, Score: 1, Reason: fmt.Sprintf("salary up to %d meets the desired %d", maxSalary, desired)}
+	}
+	return &Factor{
+		Name:   FactorSalary,
+		Score:  float64(maxSalary) / float64(desired),
+		Reason: fmt.Sprintf("salary up to %d is below the desired %d", maxSalary, desired),
+	}
+}
+
+// scoreFreshness уменьшает оценку вдвое за каждый FreshnessHalfLife с момента публикации
+func (s Scorer) scoreFreshness(vacancy *entities.Vacancy, now time.Time) *Factor {
+	if vacancy.CreatedAt.IsZero() || s.FreshnessHalfLife <= 0 {
+		return nil
+	}
+	age := now.Sub(vacancy.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	return &Factor{
+		Name:   FactorFreshness,
+		Score:  math.Pow(0.5, float64(age)/float64(s.FreshnessHalfLife)),
+		Reason: fmt.Sprintf("published %d days ago", int(age.Hours()/24)),
+	}
+}
+
+// mentions проверяет, что term встречается в text отдельным словом: "go" не находится в "google"
+func mentions(text, term string) bool {
+	for start := 0; start < len(text); {
+		i := strings.Index(text[start:], term)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		start = i + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func round(value float64, digits int) float64 {
+	pow := math.Pow(10, float64(digits))
+	return math.Round(value*pow) / pow
+}
@@ -0,0 +1,306 @@
+package matching
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func findFactor(match Match, name string) *Factor {
+	for i := range match.Factors {
+		if match.Factors[i].Name == name {
+			return &match.Factors[i]
+		}
+	}
+	return nil
+}
+
+func factorNames(match Match) []string {
+	names := make([]string, len(match.Factors))
+	for i, factor := range match.Factors {
+		names[i] = factor.Name
+	}
+	return names
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScoreVacancyWeights(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *entities.StudentProfile
+		vacancy *entities.Vacancy
+		want    float64
+		factors []string
+	}{
+		{
+			name: "all factors match",
+			profile: &entities.StudentProfile{
+				Skills:             []string{"Go", "Docker"},
+				PreferredTypes:     []string{entities.VacancyTypeFull},
+				PreferredFormats:   []string{entities.VacancyFormatOffice},
+				PreferredLocations: []string{"Алматы"},
+				DesiredSalary:      intPtr(300000),
+			},
+			vacancy: &entities.Vacancy{
+				Skills:       []string{"Go", "Docker"},
+				Requirements: []string{"Опыт разработки на Go"},
+				Type:         entities.VacancyTypeFull,
+				Format:       entities.VacancyFormatOffice,
+				Location:     "Алматы",
+				SalaryFixed:  intPtr(400000),
+				CreatedAt:    testNow,
+			},
+			want:    100,
+			factors: []string{FactorSkills, FactorRequirements, FactorType, FactorFormat, FactorLocation, FactorSalary, FactorFreshness},
+		},
+		{
+			name:    "only skills are known",
+			profile: &entities.StudentProfile{Skills: []string{"Go"}},
+			vacancy: &entities.Vacancy{Skills: []string{"Go", "Docker"}},
+			want:    50,
+			factors: []string{FactorSkills},
+		},
+		{
+			name: "skills weigh four times the type",
+			profile: &entities.StudentProfile{
+				Skills:         []string{"Go"},
+				PreferredTypes: []string{entities.VacancyTypeInternship},
+			},
+			vacancy: &entities.Vacancy{Skills: []string{"Go"}, Type: entities.VacancyTypeFull},
+			want:    80,
+			factors: []string{FactorSkills, FactorType},
+		},
+		{
+			name:    "salary below expectations is proportional",
+			profile: &entities.StudentProfile{DesiredSalary: intPtr(200000)},
+			vacancy: &entities.Vacancy{SalaryTo: intPtr(100000)},
+			want:    50,
+			factors: []string{FactorSalary},
+		},
+		{
+			name:    "freshness halves after the half-life",
+			profile: &entities.StudentProfile{},
+			vacancy: &entities.Vacancy{CreatedAt: testNow.Add(-defaultFreshnessHalfLife)},
+			want:    50,
+			factors: []string{FactorFreshness},
+		},
+		{
+			name:    "requirements match whole words only",
+			profile: &entities.StudentProfile{Skills: []string{"Go"}},
+			vacancy: &entities.Vacancy{Requirements: []string{"Опыт работы в Google", "Знание Go"}},
+			want:    50,
+			factors: []string{FactorRequirements},
+		},
+		{
+			name:    "no data gives an empty match",
+			profile: &entities.StudentProfile{},
+			vacancy: &entities.Vacancy{},
+			want:    0,
+			factors: []string{},
+		},
+	}
+
+	scorer := NewScorer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := scorer.ScoreVacancy(tt.profile, tt.vacancy, testNow)
+			if match.Score != tt.want {
+				t.Errorf("score = %v, want %v", match.Score, tt.want)
+			}
+			if names := factorNames(match); !equalStrings(names, tt.factors) {
+				t.Errorf("factors = %v, want %v", names, tt.factors)
+			}
+		})
+	}
+}
+
+func TestScoreVacancyRedistributesWeights(t *testing.T) {
+	profile := &entities.StudentProfile{
+		Skills:         []string{"Go"},
+		PreferredTypes: []string{entities.VacancyTypeInternship},
+	}
+	vacancy := &entities.Vacancy{Skills: []string{"Go"}, Type: entities.VacancyTypeFull}
+
+	tests := []struct {
+		name    string
+		weights Weights
+		want    map[string]float64
+	}{
+		{
+			name:    "default weights",
+			weights: DefaultWeights,
+			want:    map[string]float64{FactorSkills: 80, FactorType: 0},
+		},
+		{
+			name:    "equal weights",
+			weights: Weights{Skills: 1, Type: 1},
+			want:    map[string]float64{FactorSkills: 50, FactorType: 0},
+		},
+		{
+			name:    "zero weight drops the factor",
+			weights: Weights{Skills: 0, Type: 10, Format: 10},
+			want:    map[string]float64{FactorType: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := Scorer{Weights: tt.weights, FreshnessHalfLife: defaultFreshnessHalfLife}
+			match := scorer.ScoreVacancy(profile, vacancy, testNow)
+			if len(match.Factors) != len(tt.want) {
+				t.Fatalf("factors = %v, want %d factors", factorNames(match), len(tt.want))
+			}
+			total := 0.0
+			for name, want := range tt.want {
+				factor := findFactor(match, name)
+				if factor == nil {
+					t.Fatalf("factor %s is missing", name)
+				}
+				if factor.Contribution != want {
+					t.Errorf("%s contribution = %v, want %v", name, factor.Contribution, want)
+				}
+				total += factor.Contribution
+			}
+			if math.Abs(total-match.Score) > 0.1 {
+				t.Errorf("contributions sum to %v, score is %v", total, match.Score)
+			}
+		})
+	}
+}
+
+func TestScoreFormatAndLocation(t *testing.T) {
+	tests := []struct {
+		name      string
+		formats   []string
+		locations []string
+		vacancy   *entities.Vacancy
+		factor    string
+		want      float64
+	}{
+		{
+			name:    "preferred format",
+			formats: []string{entities.VacancyFormatHybrid},
+			vacancy: &entities.Vacancy{Format: entities.VacancyFormatHybrid},
+			factor:  FactorFormat,
+			want:    1,
+		},
+		{
+			name:    "hybrid half-matches office",
+			formats: []string{entities.VacancyFormatOffice},
+			vacancy: &entities.Vacancy{Format: entities.VacancyFormatHybrid},
+			factor:  FactorFormat,
+			want:    0.5,
+		},
+		{
+			name:    "hybrid half-matches remote",
+			formats: []string{entities.VacancyFormatRemote},
+			vacancy: &entities.Vacancy{Format: entities.VacancyFormatHybrid},
+			factor:  FactorFormat,
+			want:    0.5,
+		},
+		{
+			name:    "remote does not match office",
+			formats: []string{entities.VacancyFormatOffice},
+			vacancy: &entities.Vacancy{Format: entities.VacancyFormatRemote},
+			factor:  FactorFormat,
+			want:    0,
+		},
+		{
+			name:      "remote matches any location",
+			locations: []string{"Алматы"},
+			vacancy:   &entities.Vacancy{Format: entities.VacancyFormatRemote, Location: "Астана"},
+			factor:    FactorLocation,
+			want:      1,
+		},
+		{
+			name:      "office in another city",
+			locations: []string{"Алматы"},
+			vacancy:   &entities.Vacancy{Format: entities.VacancyFormatOffice, Location: "Астана"},
+			factor:    FactorLocation,
+			want:      0,
+		},
+		{
+			name:      "hybrid needs the city",
+			locations: []string{"Алматы"},
+			vacancy:   &entities.Vacancy{Format: entities.VacancyFormatHybrid, Location: "Астана"},
+			factor:    FactorLocation,
+			want:      0,
+		},
+		{
+			name:      "city compared case-insensitively",
+			locations: []string{" алматы "},
+			vacancy:   &entities.Vacancy{Format: entities.VacancyFormatOffice, Location: "Алматы"},
+			factor:    FactorLocation,
+			want:      1,
+		},
+	}
+
+	scorer := NewScorer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &entities.StudentProfile{PreferredFormats: tt.formats, PreferredLocations: tt.locations}
+			factor := findFactor(scorer.ScoreVacancy(profile, tt.vacancy, testNow), tt.factor)
+			if factor == nil {
+				t.Fatalf("factor %s is missing", tt.factor)
+			}
+			if factor.Score != tt.want {
+				t.Errorf("%s score = %v, want %v", tt.factor, factor.Score, tt.want)
+			}
+		})
+	}
+}
+
+func TestRankVacanciesTies(t *testing.T) {
+	profile := &entities.StudentProfile{Skills: []string{"Go"}}
+	older := &entities.Vacancy{ID: "older", Skills: []string{"Go"}, CreatedAt: testNow.Add(-time.Hour)}
+	newer := &entities.Vacancy{ID: "newer", Skills: []string{"Go"}, CreatedAt: testNow}
+	partial := &entities.Vacancy{ID: "partial", Skills: []string{"Go", "Docker"}, CreatedAt: testNow}
+
+	// Свежесть отключена, чтобы оценки older и newer совпадали
+	scorer := Scorer{Weights: DefaultWeights}
+	ranked := scorer.RankVacancies(profile, []*entities.Vacancy{partial, older, newer}, testNow)
+
+	got := make([]string, len(ranked))
+	for i, match := range ranked {
+		got[i] = match.Vacancy.ID
+	}
+	if want := []string{"newer", "older", "partial"}; !equalStrings(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestRankCandidatesTies(t *testing.T) {
+	vacancy := &entities.Vacancy{Skills: []string{"Go", "Docker"}}
+	stale := &entities.StudentProfile{UserID: "stale", Skills: []string{"Go", "Docker"}, UpdatedAt: testNow.Add(-time.Hour)}
+	recent := &entities.StudentProfile{UserID: "recent", Skills: []string{"Go", "Docker"}, UpdatedAt: testNow}
+	partial := &entities.StudentProfile{UserID: "partial", Skills: []string{"Go"}, UpdatedAt: testNow}
+
+	ranked := NewScorer().RankCandidates(vacancy, []*entities.StudentProfile{partial, stale, recent})
+
+	got := make([]string, len(ranked))
+	for i, match := range ranked {
+		got[i] = match.Profile.UserID
+	}
+	if want := []string{"recent", "stale", "partial"}; !equalStrings(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// ErrApplicationExists возвращается при повторном отклике студента на ту же вакансию
+var ErrApplicationExists = errors.New("application already exists")
+
+// ErrApplicationStatusConflict возвращается, если статус отклика изменился с момента чтения
+var ErrApplicationStatusConflict = errors.New("application status was changed concurrently")
+
+type ApplicationRepository interface {
+	Create(ctx context.Context, application *entities.Application) error
+	FindByID(ctx context.Context, id string) (*entities.Application, error)
+	FindByVacancyAndStudent(ctx context.Context, vacancyID, studentID string) (*entities.Application, error)
+	FindByVacancy(ctx context.Context, vacancyID string) ([]*entities.Application, error)
+	FindByStudent(ctx context.Context, studentID string) ([]*entities.Application, error)
+	// UpdateStatus переводит отклик в новый статус, только если текущий статус равен expectedStatus
+	UpdateStatus(ctx context.Context, id, expectedStatus string, change entities.ApplicationStatusChange) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+var (
+	// ErrBookmarkExists возвращается при повторном сохранении той же вакансии
+	ErrBookmarkExists = errors.New("vacancy is already saved")
+	// ErrBookmarkNotFound возвращается при удалении вакансии, которой нет в закладках
+	ErrBookmarkNotFound = errors.New("saved vacancy not found")
+)
+
+type BookmarkRepository interface {
+	Create(ctx context.Context, bookmark *entities.Bookmark) error
+	Delete(ctx context.Context, userID, vacancyID string) error
+	// FindByUser возвращает закладки пользователя, новые первыми
+	FindByUser(ctx context.Context, userID string) ([]*entities.Bookmark, error)
+	// FindUserIDsByVacancy возвращает пользователей, сохранивших вакансию
+	FindUserIDsByVacancy(ctx context.Context, vacancyID string) ([]string, error)
+	// SavedVacancyIDs возвращает, какие из vacancyIDs сохранены пользователем
+	SavedVacancyIDs(ctx context.Context, userID string, vacancyIDs []string) (map[string]bool, error)
+}
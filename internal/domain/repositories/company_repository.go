@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// ErrCompanyTeamConflict возвращается, если состав команды изменился с момента чтения
+var ErrCompanyTeamConflict = errors.New("company team was changed concurrently")
+
+type CompanyRepository interface {
+	Create(ctx context.Context, company *entities.Company) error
+	// FindByID возвращает nil, если компания не найдена
+	FindByID(ctx context.Context, id string) (*entities.Company, error)
+	// FindByIDs возвращает найденные компании по ID; отсутствующие ID пропускаются
+	FindByIDs(ctx context.Context, ids []string) (map[string]*entities.Company, error)
+	// FindByMember возвращает компании, в команде которых состоит пользователь
+	FindByMember(ctx context.Context, userID string) ([]*entities.Company, error)
+	Update(ctx context.Context, company *entities.Company) error
+	// AddMember добавляет участника, только если его еще нет в команде
+	AddMember(ctx context.Context, id string, member entities.CompanyMember) error
+	// SetMemberRole меняет роль участника, только если его текущая роль равна expectedRole.
+	// Владельца можно понизить, только если в команде есть другой владелец.
+	SetMemberRole(ctx context.Context, id, userID, expectedRole, role string) error
+	// RemoveMember исключает участника, только если его текущая роль равна expectedRole.
+	// Владельца можно исключить, только если в команде есть другой владелец.
+	RemoveMember(ctx context.Context, id, userID, expectedRole string) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+var (
+	// ErrConversationNotFound возвращается, если переписки нет или пользователь в ней не участвует
+	ErrConversationNotFound = errors.New("conversation not found")
+	// ErrMessageNotFound возвращается, если сообщения нет в переписке
+	ErrMessageNotFound = errors.New("message not found")
+)
+
+// ConversationParticipant фильтр переписок, доступных пользователю: как студенту, как автору вакансий
+// и как участнику команд компаний
+type ConversationParticipant struct {
+	StudentID  string
+	EmployerID string
+	CompanyIDs []string
+}
+
+type ConversationRepository interface {
+	// FindOrCreate возвращает переписку по паре (студент, вакансия), создавая ее при отсутствии
+	FindOrCreate(ctx context.Context, conversation *entities.Conversation) (*entities.Conversation, error)
+	// FindByID возвращает nil, если переписка не найдена
+	FindByID(ctx context.Context, id string) (*entities.Conversation, error)
+	// FindByParticipant возвращает переписки пользователя, последние активные первыми
+	FindByParticipant(ctx context.Context, participant ConversationParticipant, limit, offset int) ([]*entities.Conversation, error)
+	CountByParticipant(ctx context.Context, participant ConversationParticipant) (int64, error)
+	// CountUnread суммирует непрочитанные сообщения стороны side по перепискам пользователя
+	CountUnread(ctx context.Context, participant ConversationParticipant, side string) (int64, error)
+	// AddMessage сохраняет последнее сообщение и увеличивает счетчик непрочитанных получателя
+	AddMessage(ctx context.Context, id string, message *entities.Message, recipientSide string) error
+	// SetLastMessage заменяет последнее сообщение после его изменения или удаления
+	SetLastMessage(ctx context.Context, id string, message *entities.Message) error
+	// AdjustUnread изменяет счетчик непрочитанных стороны side на delta, не опуская его ниже нуля
+	AdjustUnread(ctx context.Context, id, side string, delta int) error
+}
+
+type MessageRepository interface {
+	Create(ctx context.Context, message *entities.Message) error
+	// FindByID возвращает nil, если сообщение не найдено
+	FindByID(ctx context.Context, id string) (*entities.Message, error)
+	// FindByConversation возвращает сообщения переписки, новые первыми; before — ID сообщения, с которого начать
+	FindByConversation(ctx context.Context, conversationID, before string, limit int) ([]*entities.Message, error)
+	// FindLast возвращает последнее неудаленное сообщение переписки или nil
+	FindLast(ctx context.Context, conversationID string) (*entities.Message, error)
+	Update(ctx context.Context, message *entities.Message) error
+	// MarkRead отмечает прочитанными сообщения стороны senderSide и возвращает их количество
+	MarkRead(ctx context.Context, conversationID, senderSide string, at time.Time) (int64, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// ErrNotificationNotFound возвращается, если уведомления нет или оно адресовано другому пользователю
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type NotificationRepository interface {
+	CreateMany(ctx context.Context, notifications []*entities.Notification) error
+	// FindByUser возвращает страницу уведомлений пользователя: сначала непрочитанные, внутри — новые первыми
+	FindByUser(ctx context.Context, userID string, limit, offset int) ([]*entities.Notification, error)
+	// FindAfter возвращает уведомления пользователя, созданные после уведомления afterID, в порядке создания
+	FindAfter(ctx context.Context, userID, afterID string, limit int) ([]*entities.Notification, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID, id string) error
+	// MarkAllRead отмечает прочитанными все уведомления пользователя и возвращает их количество
+	MarkAllRead(ctx context.Context, userID string) (int64, error)
+	Delete(ctx context.Context, userID, id string) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitRepository хранит счетчики событий в фиксированных окнах времени
+type RateLimitRepository interface {
+	// Hit увеличивает счетчик ключа. Если окно истекло, начинает новое окно длиной window.
+	// Возвращает значение счетчика после увеличения и время окончания окна.
+	Hit(ctx context.Context, key string, window time.Duration) (int, time.Time, error)
+	// Get возвращает текущее значение счетчика и время окончания окна; 0, если окно истекло
+	Get(ctx context.Context, key string) (int, time.Time, error)
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// ErrRefreshTokenRevoked возвращается при попытке отозвать уже отозванный токен
+var ErrRefreshTokenRevoked = errors.New("refresh token already revoked")
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *entities.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// Revoke отзывает токен, только если он еще не был отозван, иначе возвращает ErrRefreshTokenRevoked
+	Revoke(ctx context.Context, id, replacedBy string) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
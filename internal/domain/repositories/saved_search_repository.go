@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// ErrSavedSearchNotFound возвращается, если сохраненного поиска нет или он принадлежит другому пользователю
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *entities.SavedSearch) error
+	// FindByID возвращает nil, если поиск не найден
+	FindByID(ctx context.Context, id string) (*entities.SavedSearch, error)
+	// FindByUser возвращает поиски пользователя, новые первыми
+	FindByUser(ctx context.Context, userID string) ([]*entities.SavedSearch, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	// FindMatchCandidates предварительно отбирает поиски, чьи тип, формат и город допускают вакансию.
+	// Окончательная проверка выполняется вызывающим кодом.
+	FindMatchCandidates(ctx context.Context, vacancy *entities.Vacancy) ([]*entities.SavedSearch, error)
+	// FindDigestsDue возвращает поиски с частотой frequency, у которых есть ожидающие вакансии
+	// и последняя сводка отправлена не позже notifiedBefore
+	FindDigestsDue(ctx context.Context, frequency string, notifiedBefore time.Time) ([]*entities.SavedSearch, error)
+	Update(ctx context.Context, search *entities.SavedSearch) error
+	// AddPending добавляет вакансию в ожидающие сводки
+	AddPending(ctx context.Context, id, vacancyID string) error
+	// MarkNotified убирает отправленные вакансии из ожидающих и запоминает время оповещения
+	MarkNotified(ctx context.Context, id string, vacancyIDs []string, at time.Time) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+var (
+	// ErrStudentProfileExists возвращается при создании второго профиля для того же студента
+	ErrStudentProfileExists = errors.New("student profile already exists")
+	// ErrStudentProfileNotFound возвращается, если у студента еще нет профиля
+	ErrStudentProfileNotFound = errors.New("student profile not found")
+)
+
+type StudentProfileRepository interface {
+	Create(ctx context.Context, profile *entities.StudentProfile) error
+	// FindByUserID возвращает nil, если профиль не создан
+	FindByUserID(ctx context.Context, userID string) (*entities.StudentProfile, error)
+	// FindDiscoverable возвращает до limit недавно обновленных профилей студентов, согласившихся на подбор.
+	// Если skills не пуст, отбираются профили хотя бы с одним из навыков.
+	FindDiscoverable(ctx context.Context, skills []string, limit int) ([]*entities.StudentProfile, error)
+	Update(ctx context.Context, profile *entities.StudentProfile) error
+	Delete(ctx context.Context, userID string) error
+}
@@ -2,19 +2,28 @@ package repositories
 
 import (
     "context"
+    "errors"
+    "time"
+
     "github.com/albkvv/student-job-finder-back/internal/domain/entities"
 )
 
+// ErrUserExists возвращается при создании пользователя с уже занятым email или телефоном
+var ErrUserExists = errors.New("user with this email or phone already exists")
+
 type UserRepository interface {
     Create(ctx context.Context, user *entities.User) error
     FindByEmail(ctx context.Context, email string) (*entities.User, error)
     FindByPhone(ctx context.Context, phone string) (*entities.User, error)
     FindByID(ctx context.Context, id string) (*entities.User, error)
     Update(ctx context.Context, user *entities.User) error
+    // DeleteUnverifiedBefore удаляет неподтвержденных пользователей без пароля, созданных раньше before
+    DeleteUnverifiedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type VerificationCodeRepository interface {
-    SetCode(ctx context.Context, identifier, code, codeType string, expiresAt int64) error
+    // SetCode сохраняет код, заменяя предыдущий код того же типа для идентификатора
+    SetCode(ctx context.Context, code *entities.VerificationCode) error
     GetCode(ctx context.Context, identifier, codeType string) (*entities.VerificationCode, error)
     IncrementAttempts(ctx context.Context, identifier, codeType string) error
     DeleteCode(ctx context.Context, identifier, codeType string) error
@@ -2,13 +2,63 @@ package repositories
 
 import (
 	"context"
+	"errors"
+
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
 )
 
+// VacancySort константы для вариантов сортировки списка вакансий
+const (
+	VacancySortNewest   = "newest"   // сначала новые
+	VacancySortDeadline = "deadline" // сначала с ближайшим дедлайном
+	VacancySortSalary   = "salary"   // по убыванию зарплаты
+	VacancySortViews    = "views"    // по убыванию просмотров
+)
+
+// ErrInvalidCursor возвращается, если курсор пагинации поврежден или не соответствует сортировке
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// VacancyFilter критерии отбора вакансий; пустые поля не ограничивают выборку
+type VacancyFilter struct {
+	CompanyID string
+	Status    string
+	Type      string
+	Format    string
+	Location  string
+	// MinSalary отбирает вакансии, у которых salary_fixed или salary_to не меньше указанного значения
+	MinSalary *int
+	Skills    []string
+	// SkillsMatchAll требует наличия всех навыков из Skills, иначе достаточно любого
+	SkillsMatchAll bool
+	// NotExpired исключает вакансии с прошедшим дедлайном; вакансии без дедлайна остаются
+	NotExpired bool
+}
+
+// VacancyPageRequest параметры страницы списка вакансий.
+// Если задан Cursor, Offset игнорируется.
+type VacancyPageRequest struct {
+	Limit  int
+	Offset int
+	Cursor string
+	Sort   string
+}
+
+// VacancyPage страница списка вакансий
+type VacancyPage struct {
+	Items      []*entities.Vacancy
+	Total      int64
+	NextCursor string
+}
+
 type VacancyRepository interface {
 	Create(ctx context.Context, vacancy *entities.Vacancy) error
 	FindByID(ctx context.Context, id string) (*entities.Vacancy, error)
-	FindAll(ctx context.Context, status string) ([]*entities.Vacancy, error)
+	// FindByIDs возвращает найденные вакансии по ID; отсутствующих в результате нет
+	FindByIDs(ctx context.Context, ids []string) (map[string]*entities.Vacancy, error)
+	FindAll(ctx context.Context, filter VacancyFilter, page VacancyPageRequest) (*VacancyPage, error)
+	// FindByEmployer возвращает вакансии автора и вакансии компаний из companyIDs, новые первыми
+	FindByEmployer(ctx context.Context, employerID string, companyIDs []string) ([]*entities.Vacancy, error)
+	// Update сохраняет поля вакансии, кроме статуса: он меняется только через UpdateStatus
 	Update(ctx context.Context, vacancy *entities.Vacancy) error
 	UpdateStatus(ctx context.Context, id string, status string) error
 	Delete(ctx context.Context, id string) error
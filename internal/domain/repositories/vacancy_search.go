@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// VacancySearchWeights веса полей вакансии при расчете релевантности полнотекстового поиска
+var VacancySearchWeights = map[string]int{
+	"title":            10,
+	"skills":           5,
+	"requirements":     2,
+	"responsibilities": 2,
+	"description":      1,
+}
+
+// VacancySearchResult найденная вакансия и ее релевантность
+type VacancySearchResult struct {
+	Vacancy *entities.Vacancy `json:"vacancy"`
+	Score   float64           `json:"score"`
+}
+
+// VacancySearchPage страница результатов поиска, отсортированная по убыванию релевантности
+type VacancySearchPage struct {
+	Items []VacancySearchResult
+	Total int64
+}
+
+type VacancySearcher interface {
+	Search(ctx context.Context, query string, filter VacancyFilter, limit, offset int) (*VacancySearchPage, error)
+}
+
+// Matches проверяет вакансию на соответствие фильтру без обращения к хранилищу
+func (f VacancyFilter) Matches(v *entities.Vacancy, now time.Time) bool {
+	if f.CompanyID != "" && v.CompanyID != f.CompanyID {
+		return false
+	}
+	if f.Status != "" && v.Status != f.Status {
+		return false
+	}
+	if f.Type != "" && v.Type != f.Type {
+		return false
+	}
+	if f.Format != "" && v.Format != f.Format {
+		return false
+	}
+	if f.Location != "" && v.Location != f.Location {
+		return false
+	}
+	if f.MinSalary != nil {
+		fixedOK := v.SalaryFixed != nil && *v.SalaryFixed >= *f.MinSalary
+		rangeOK := v.SalaryTo != nil && *v.SalaryTo >= *f.MinSalary
+		if !fixedOK && !rangeOK {
+			return false
+		}
+	}
+	if f.NotExpired && !v.Deadline.IsZero() && v.Deadline.Before(now) {
+		return false
+	}
+	if len(f.Skills) > 0 {
+		has := make(map[string]bool, len(v.Skills))
+		for _, skill := range v.Skills {
+			has[skill] = true
+		}
+		matched := 0
+		for _, skill := range f.Skills {
+			if has[skill] {
+				matched++
+			}
+		}
+		if f.SkillsMatchAll && matched < len(f.Skills) {
+			return false
+		}
+		if !f.SkillsMatchAll && matched == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+)
+
+// SMSSender отправляет SMS-сообщения
+type SMSSender interface {
+	SendSMS(ctx context.Context, phone, message string) error
+}
+
+// EmailSender отправляет электронные письма
+type EmailSender interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// AlertNotifier доставляет пользователю оповещение сохраненного поиска
+type AlertNotifier interface {
+	NotifyVacancyAlert(ctx context.Context, alert entities.VacancyAlert) error
+}
+
+// NotificationPublisher публикует уведомления пользователям.
+// Все события приложения (отклики, смена этапов, закрытие вакансий, оповещения поисков) проходят через него.
+type NotificationPublisher interface {
+	Publish(ctx context.Context, notifications ...*entities.Notification) error
+}
+
+// EventBroker доставляет события подключенным клиентам пользователей.
+// Реализация в памяти процесса работает на одном узле; для нескольких узлов ее можно заменить общей шиной.
+type EventBroker interface {
+	Publish(ctx context.Context, events ...entities.Event) error
+	// Subscribe подписывает клиента на события пользователя до отмены ctx.
+	// Канал закрывается при отписке или если клиент не успевает читать события.
+	Subscribe(ctx context.Context, userID string) (<-chan entities.Event, error)
+}
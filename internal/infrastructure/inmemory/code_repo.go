@@ -21,17 +21,13 @@ func NewInMemoryCodeRepo() repositories.VerificationCodeRepository {
 	}
 }
 
-func (r *InMemoryCodeRepo) SetCode(ctx context.Context, identifier, code, codeType string, expiresAt int64) error {
+func (r *InMemoryCodeRepo) SetCode(ctx context.Context, code *entities.VerificationCode) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	key := fmt.Sprintf("%s:%s", identifier, codeType)
-	r.codes[key] = &entities.VerificationCode{
-		Identifier: identifier,
-		Code:       code,
-		Type:       codeType,
-		Attempts:   0,
-		ExpiresAt:  expiresAt,
-	}
+	key := fmt.Sprintf("%s:%s", code.Identifier, code.Type)
+	stored := *code
+	stored.Attempts = 0
+	r.codes[key] = &stored
 	return nil
 }
 
@@ -0,0 +1,90 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+)
+
+// eventSubscriberBuffer сколько событий может ждать доставки одному клиенту
+const eventSubscriberBuffer = 64
+
+type eventSubscriber struct {
+	userID string
+	events chan entities.Event
+	once   sync.Once
+}
+
+func (s *eventSubscriber) close() {
+	s.once.Do(func() { close(s.events) })
+}
+
+// InMemoryEventBroker рассылает события подписчикам внутри одного процесса
+type InMemoryEventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[*eventSubscriber]struct{}
+}
+
+func NewInMemoryEventBroker() services.EventBroker {
+	return &InMemoryEventBroker{
+		subscribers: make(map[string]map[*eventSubscriber]struct{}),
+	}
+}
+
+// Publish не блокируется на медленных клиентах: клиент с переполненным буфером отключается
+// и при переподключении догоняет пропущенное по Last-Event-ID.
+func (b *InMemoryEventBroker) Publish(ctx context.Context, events ...entities.Event) error {
+	var lagging []*eventSubscriber
+
+	b.mu.RLock()
+	for _, event := range events {
+		for subscriber := range b.subscribers[event.UserID] {
+			select {
+			case subscriber.events <- event:
+			default:
+				lagging = append(lagging, subscriber)
+			}
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, subscriber := range lagging {
+		b.remove(subscriber)
+	}
+	return nil
+}
+
+func (b *InMemoryEventBroker) Subscribe(ctx context.Context, userID string) (<-chan entities.Event, error) {
+	subscriber := &eventSubscriber{
+		userID: userID,
+		events: make(chan entities.Event, eventSubscriberBuffer),
+	}
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*eventSubscriber]struct{})
+	}
+	b.subscribers[userID][subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.remove(subscriber)
+	}()
+	return subscriber.events, nil
+}
+
+// remove отписывает клиента и закрывает его канал; повторный вызов безопасен
+func (b *InMemoryEventBroker) remove(subscriber *eventSubscriber) {
+	b.mu.Lock()
+	if subscribers, ok := b.subscribers[subscriber.userID]; ok {
+		delete(subscribers, subscriber)
+		if len(subscribers) == 0 {
+			delete(b.subscribers, subscriber.userID)
+		}
+	}
+	b.mu.Unlock()
+	subscriber.close()
+}
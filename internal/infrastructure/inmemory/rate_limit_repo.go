@@ -0,0 +1,61 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+type rateLimitCounter struct {
+	count   int
+	resetAt time.Time
+}
+
+type InMemoryRateLimitRepo struct {
+	mu       sync.Mutex
+	counters map[string]*rateLimitCounter
+}
+
+func NewInMemoryRateLimitRepo() repositories.RateLimitRepository {
+	return &InMemoryRateLimitRepo{
+		counters: make(map[string]*rateLimitCounter),
+	}
+}
+
+func (r *InMemoryRateLimitRepo) Hit(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	counter, ok := r.counters[key]
+	if !ok || !now.Before(counter.resetAt) {
+		counter = &rateLimitCounter{resetAt: now.Add(window)}
+		r.counters[key] = counter
+	}
+	counter.count++
+	return counter.count, counter.resetAt, nil
+}
+
+func (r *InMemoryRateLimitRepo) Get(ctx context.Context, key string) (int, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counter, ok := r.counters[key]
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+	if !time.Now().Before(counter.resetAt) {
+		delete(r.counters, key)
+		return 0, time.Time{}, nil
+	}
+	return counter.count, counter.resetAt, nil
+}
+
+func (r *InMemoryRateLimitRepo) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.counters, key)
+	return nil
+}
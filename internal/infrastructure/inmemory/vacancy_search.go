@@ -0,0 +1,121 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+)
+
+// indexedVacancy вакансия и частоты основ слов по полям
+type indexedVacancy struct {
+	vacancy *entities.Vacancy
+	terms   map[string]map[string]int // поле -> основа -> количество вхождений
+}
+
+// InMemoryVacancySearch полнотекстовый поиск по вакансиям в памяти процесса.
+// Ранжирует результаты с теми же весами полей, что и текстовый индекс MongoDB.
+type InMemoryVacancySearch struct {
+	mu        sync.RWMutex
+	vacancies map[string]*indexedVacancy
+}
+
+func NewInMemoryVacancySearch(vacancies ...*entities.Vacancy) *InMemoryVacancySearch {
+	s := &InMemoryVacancySearch{
+		vacancies: make(map[string]*indexedVacancy),
+	}
+	for _, v := range vacancies {
+		s.Index(v)
+	}
+	return s
+}
+
+// Index добавляет вакансию в индекс или обновляет ее
+func (s *InMemoryVacancySearch) Index(vacancy *entities.Vacancy) {
+	fields := map[string]string{
+		"title":            vacancy.Title,
+		"skills":           strings.Join(vacancy.Skills, " "),
+		"requirements":     strings.Join(vacancy.Requirements, " "),
+		"responsibilities": strings.Join(vacancy.Responsibilities, " "),
+		"description":      vacancy.Description,
+	}
+
+	terms := make(map[string]map[string]int, len(fields))
+	for field, text := range fields {
+		counts := make(map[string]int)
+		for _, term := range utils.StemTokens(text) {
+			counts[term]++
+		}
+		terms[field] = counts
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vacancies[vacancy.ID] = &indexedVacancy{vacancy: vacancy, terms: terms}
+}
+
+// Remove удаляет вакансию из индекса
+func (s *InMemoryVacancySearch) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.vacancies, id)
+}
+
+func (s *InMemoryVacancySearch) Search(ctx context.Context, query string, filter repositories.VacancyFilter, limit, offset int) (*repositories.VacancySearchPage, error) {
+	queryTerms := uniqueTerms(utils.StemTokens(query))
+	now := time.Now()
+
+	s.mu.RLock()
+	results := []repositories.VacancySearchResult{}
+	for _, doc := range s.vacancies {
+		if !filter.Matches(doc.vacancy, now) {
+			continue
+		}
+		score := 0.0
+		for field, weight := range repositories.VacancySearchWeights {
+			for _, term := range queryTerms {
+				score += float64(weight * doc.terms[field][term])
+			}
+		}
+		if score > 0 {
+			results = append(results, repositories.VacancySearchResult{Vacancy: doc.vacancy, Score: score})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Vacancy.CreatedAt.After(results[j].Vacancy.CreatedAt)
+	})
+
+	page := &repositories.VacancySearchPage{Total: int64(len(results))}
+	if offset >= len(results) {
+		page.Items = []repositories.VacancySearchResult{}
+		return page, nil
+	}
+	end := offset + limit
+	if end > len(results) {
+		end = len(results)
+	}
+	page.Items = results[offset:end]
+	return page, nil
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]bool, len(terms))
+	unique := terms[:0]
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			unique = append(unique, term)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,139 @@
+package inmemory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+)
+
+func resultIDs(page *repositories.VacancySearchPage) []string {
+	ids := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		ids[i] = item.Vacancy.ID
+	}
+	return ids
+}
+
+func TestVacancySearchFieldWeights(t *testing.T) {
+	now := time.Now()
+	search := NewInMemoryVacancySearch(
+		&entities.Vacancy{ID: "description", Description: "Пишем сервисы на Go", CreatedAt: now},
+		&entities.Vacancy{ID: "title", Title: "Go-разработчик", CreatedAt: now},
+		&entities.Vacancy{ID: "requirements", Requirements: []string{"Опыт с Go"}, CreatedAt: now},
+		&entities.Vacancy{ID: "skills", Skills: []string{"Go", "Docker"}, CreatedAt: now},
+		&entities.Vacancy{ID: "unrelated", Title: "Дизайнер", CreatedAt: now},
+	)
+
+	page, err := search.Search(context.Background(), "go", repositories.VacancyFilter{}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"title", "skills", "requirements", "description"}; !reflect.DeepEqual(resultIDs(page), want) {
+		t.Errorf("order = %v, want %v", resultIDs(page), want)
+	}
+	for _, item := range page.Items {
+		field := item.Vacancy.ID
+		if want := float64(repositories.VacancySearchWeights[field]); item.Score != want {
+			t.Errorf("%s score = %v, want %v", field, item.Score, want)
+		}
+	}
+	if page.Total != 4 {
+		t.Errorf("total = %d, want 4", page.Total)
+	}
+}
+
+func TestVacancySearchScoring(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		vacancy *entities.Vacancy
+		query   string
+		want    float64
+	}{
+		{
+			name:    "word forms share a stem",
+			vacancy: &entities.Vacancy{Title: "Разработчик"},
+			query:   "разработчиков",
+			want:    10,
+		},
+		{
+			name:    "repeated words in a field add up",
+			vacancy: &entities.Vacancy{Description: "Go, снова Go и еще раз Go"},
+			query:   "go",
+			want:    3,
+		},
+		{
+			name:    "repeated query words count once",
+			vacancy: &entities.Vacancy{Title: "Go"},
+			query:   "go go",
+			want:    10,
+		},
+		{
+			name:    "fields add up",
+			vacancy: &entities.Vacancy{Title: "Go", Skills: []string{"Go"}, Description: "Go"},
+			query:   "go",
+			want:    16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.vacancy.ID = "v"
+			tt.vacancy.CreatedAt = now
+			page, err := NewInMemoryVacancySearch(tt.vacancy).Search(context.Background(), tt.query, repositories.VacancyFilter{}, 10, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page.Items) != 1 {
+				t.Fatalf("found %d vacancies, want 1", len(page.Items))
+			}
+			if page.Items[0].Score != tt.want {
+				t.Errorf("score = %v, want %v", page.Items[0].Score, tt.want)
+			}
+		})
+	}
+}
+
+func TestVacancySearchTiesFilterAndPaging(t *testing.T) {
+	now := time.Now()
+	search := NewInMemoryVacancySearch(
+		&entities.Vacancy{ID: "old", Title: "Go", Status: entities.VacancyStatusActive, CreatedAt: now.Add(-2 * time.Hour)},
+		&entities.Vacancy{ID: "new", Title: "Go", Status: entities.VacancyStatusActive, CreatedAt: now},
+		&entities.Vacancy{ID: "middle", Title: "Go", Status: entities.VacancyStatusActive, CreatedAt: now.Add(-time.Hour)},
+		&entities.Vacancy{ID: "closed", Title: "Go", Status: entities.VacancyStatusClosed, CreatedAt: now},
+	)
+	filter := repositories.VacancyFilter{Status: entities.VacancyStatusActive}
+
+	page, err := search.Search(context.Background(), "go", filter, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"new", "middle"}; !reflect.DeepEqual(resultIDs(page), want) {
+		t.Errorf("first page = %v, want %v", resultIDs(page), want)
+	}
+	if page.Total != 3 {
+		t.Errorf("total = %d, want 3", page.Total)
+	}
+
+	page, err = search.Search(context.Background(), "go", filter, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"old"}; !reflect.DeepEqual(resultIDs(page), want) {
+		t.Errorf("second page = %v, want %v", resultIDs(page), want)
+	}
+
+	search.Remove("new")
+	page, err = search.Search(context.Background(), "go", filter, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"middle", "old"}; !reflect.DeepEqual(resultIDs(page), want) {
+		t.Errorf("after remove = %v, want %v", resultIDs(page), want)
+	}
+}
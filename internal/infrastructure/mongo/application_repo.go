@@ -0,0 +1,125 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoApplicationRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoApplicationRepo(coll *mongo.Collection) repositories.ApplicationRepository {
+	return &MongoApplicationRepo{
+		coll: coll,
+	}
+}
+
+// EnsureApplicationIndexes создает индексы коллекции откликов.
+// Уникальный индекс по (vacancy_id, student_id) защищает от повторных откликов при гонках.
+func EnsureApplicationIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "vacancy_id", Value: 1}, {Key: "student_id", Value: 1}},
+			Options: options.Index().SetName("vacancy_id_student_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "student_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("student_id_created_at"),
+		},
+	})
+	return err
+}
+
+func (r *MongoApplicationRepo) Create(ctx context.Context, application *entities.Application) error {
+	application.ID = primitive.NewObjectID().Hex()
+	application.CreatedAt = time.Now()
+	application.UpdatedAt = application.CreatedAt
+
+	_, err := r.coll.InsertOne(ctx, application)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrApplicationExists
+	}
+	return err
+}
+
+func (r *MongoApplicationRepo) FindByID(ctx context.Context, id string) (*entities.Application, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.New("invalid application ID format")
+	}
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *MongoApplicationRepo) FindByVacancyAndStudent(ctx context.Context, vacancyID, studentID string) (*entities.Application, error) {
+	return r.findOne(ctx, bson.M{"vacancy_id": vacancyID, "student_id": studentID})
+}
+
+func (r *MongoApplicationRepo) FindByVacancy(ctx context.Context, vacancyID string) ([]*entities.Application, error) {
+	return r.find(ctx, bson.M{"vacancy_id": vacancyID})
+}
+
+func (r *MongoApplicationRepo) FindByStudent(ctx context.Context, studentID string) ([]*entities.Application, error) {
+	return r.find(ctx, bson.M{"student_id": studentID})
+}
+
+func (r *MongoApplicationRepo) UpdateStatus(ctx context.Context, id, expectedStatus string, change entities.ApplicationStatusChange) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid application ID format")
+	}
+
+	filter := bson.M{"_id": id, "status": expectedStatus}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     change.To,
+			"updated_at": change.ChangedAt,
+		},
+		"$push": bson.M{
+			"history": change,
+		},
+	}
+
+	result, err := r.coll.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrApplicationStatusConflict
+	}
+	return nil
+}
+
+func (r *MongoApplicationRepo) findOne(ctx context.Context, filter bson.M) (*entities.Application, error) {
+	var application entities.Application
+	err := r.coll.FindOne(ctx, filter).Decode(&application)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+func (r *MongoApplicationRepo) find(ctx context.Context, filter bson.M) ([]*entities.Application, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	applications := []*entities.Application{}
+	if err := cursor.All(ctx, &applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
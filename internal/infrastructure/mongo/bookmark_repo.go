@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoBookmarkRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoBookmarkRepo(coll *mongo.Collection) repositories.BookmarkRepository {
+	return &MongoBookmarkRepo{
+		coll: coll,
+	}
+}
+
+// EnsureBookmarkIndexes создает индексы коллекции закладок.
+// Уникальный индекс по (user_id, vacancy_id) не дает сохранить вакансию дважды.
+func EnsureBookmarkIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "vacancy_id", Value: 1}},
+			Options: options.Index().SetName("user_id_vacancy_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("user_id_created_at"),
+		},
+	})
+	return err
+}
+
+func (r *MongoBookmarkRepo) Create(ctx context.Context, bookmark *entities.Bookmark) error {
+	bookmark.ID = primitive.NewObjectID().Hex()
+	bookmark.CreatedAt = time.Now()
+
+	_, err := r.coll.InsertOne(ctx, bookmark)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrBookmarkExists
+	}
+	return err
+}
+
+func (r *MongoBookmarkRepo) Delete(ctx context.Context, userID, vacancyID string) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"user_id": userID, "vacancy_id": vacancyID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrBookmarkNotFound
+	}
+	return nil
+}
+
+func (r *MongoBookmarkRepo) FindByUser(ctx context.Context, userID string) ([]*entities.Bookmark, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.coll.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	bookmarks := []*entities.Bookmark{}
+	if err := cursor.All(ctx, &bookmarks); err != nil {
+		return nil, err
+	}
+	return bookmarks, nil
+}
+
+func (r *MongoBookmarkRepo) FindUserIDsByVacancy(ctx context.Context, vacancyID string) ([]string, error) {
+	values, err := r.coll.Distinct(ctx, "user_id", bson.M{"vacancy_id": vacancyID})
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if userID, ok := value.(string); ok {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *MongoBookmarkRepo) SavedVacancyIDs(ctx context.Context, userID string, vacancyIDs []string) (map[string]bool, error) {
+	saved := make(map[string]bool, len(vacancyIDs))
+	if len(vacancyIDs) == 0 {
+		return saved, nil
+	}
+
+	filter := bson.M{"user_id": userID, "vacancy_id": bson.M{"$in": vacancyIDs}}
+	opts := options.Find().SetProjection(bson.M{"vacancy_id": 1})
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var bookmark entities.Bookmark
+		if err := cursor.Decode(&bookmark); err != nil {
+			return nil, err
+		}
+		saved[bookmark.VacancyID] = true
+	}
+	return saved, cursor.Err()
+}
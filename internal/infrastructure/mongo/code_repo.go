@@ -0,0 +1,103 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// verificationCodeDoc документ кода подтверждения.
+// Срок действия хранится как дата, чтобы на нем работал TTL-индекс.
+type verificationCodeDoc struct {
+	ID          string    `bson:"_id"`
+	Identifier  string    `bson:"identifier"`
+	CodeHash    string    `bson:"code_hash"`
+	Type        string    `bson:"type"`
+	Attempts    int       `bson:"attempts"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+	PendingRole string    `bson:"pending_role,omitempty"`
+}
+
+type MongoCodeRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoCodeRepo(coll *mongo.Collection) repositories.VerificationCodeRepository {
+	return &MongoCodeRepo{
+		coll: coll,
+	}
+}
+
+// EnsureCodeIndexes создает TTL-индекс, удаляющий просроченные коды
+func EnsureCodeIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func codeKey(identifier, codeType string) string {
+	return identifier + ":" + codeType
+}
+
+func (r *MongoCodeRepo) SetCode(ctx context.Context, code *entities.VerificationCode) error {
+	doc := verificationCodeDoc{
+		ID:          codeKey(code.Identifier, code.Type),
+		Identifier:  code.Identifier,
+		CodeHash:    code.CodeHash,
+		Type:        code.Type,
+		Attempts:    0,
+		ExpiresAt:   time.Unix(code.ExpiresAt, 0),
+		PendingRole: code.PendingRole,
+	}
+
+	_, err := r.coll.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *MongoCodeRepo) GetCode(ctx context.Context, identifier, codeType string) (*entities.VerificationCode, error) {
+	var doc verificationCodeDoc
+	err := r.coll.FindOne(ctx, bson.M{"_id": codeKey(identifier, codeType)}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// TTL-монитор удаляет документы с задержкой, поэтому срок проверяется явно
+	if time.Now().After(doc.ExpiresAt) {
+		return nil, nil
+	}
+
+	return &entities.VerificationCode{
+		Identifier:  doc.Identifier,
+		CodeHash:    doc.CodeHash,
+		Type:        doc.Type,
+		Attempts:    doc.Attempts,
+		ExpiresAt:   doc.ExpiresAt.Unix(),
+		PendingRole: doc.PendingRole,
+	}, nil
+}
+
+func (r *MongoCodeRepo) IncrementAttempts(ctx context.Context, identifier, codeType string) error {
+	update := bson.M{
+		"$inc": bson.M{
+			"attempts": 1,
+		},
+	}
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": codeKey(identifier, codeType)}, update)
+	return err
+}
+
+func (r *MongoCodeRepo) DeleteCode(ctx context.Context, identifier, codeType string) error {
+	_, err := r.coll.DeleteOne(ctx, bson.M{"_id": codeKey(identifier, codeType)})
+	return err
+}
@@ -0,0 +1,216 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoCompanyRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoCompanyRepo(coll *mongo.Collection) repositories.CompanyRepository {
+	return &MongoCompanyRepo{
+		coll: coll,
+	}
+}
+
+// EnsureCompanyIndexes создает индекс для поиска компаний пользователя
+func EnsureCompanyIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "members.user_id", Value: 1}},
+		Options: options.Index().SetName("members_user_id"),
+	})
+	return err
+}
+
+func (r *MongoCompanyRepo) Create(ctx context.Context, company *entities.Company) error {
+	company.ID = primitive.NewObjectID().Hex()
+	company.CreatedAt = time.Now()
+	company.UpdatedAt = company.CreatedAt
+
+	_, err := r.coll.InsertOne(ctx, company)
+	return err
+}
+
+func (r *MongoCompanyRepo) FindByID(ctx context.Context, id string) (*entities.Company, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.New("invalid company ID format")
+	}
+
+	var company entities.Company
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&company)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *MongoCompanyRepo) FindByIDs(ctx context.Context, ids []string) (map[string]*entities.Company, error) {
+	companies := make(map[string]*entities.Company, len(ids))
+	if len(ids) == 0 {
+		return companies, nil
+	}
+
+	cursor, err := r.coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var company entities.Company
+		if err := cursor.Decode(&company); err != nil {
+			return nil, err
+		}
+		companies[company.ID] = &company
+	}
+	return companies, cursor.Err()
+}
+
+func (r *MongoCompanyRepo) FindByMember(ctx context.Context, userID string) ([]*entities.Company, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := r.coll.Find(ctx, bson.M{"members.user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	companies := []*entities.Company{}
+	if err := cursor.All(ctx, &companies); err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (r *MongoCompanyRepo) Update(ctx context.Context, company *entities.Company) error {
+	company.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         company.Name,
+			"description":  company.Description,
+			"logo_url":     company.LogoURL,
+			"website":      company.Website,
+			"industry":     company.Industry,
+			"size":         company.Size,
+			"city":         company.City,
+			"social_links": company.SocialLinks,
+			"updated_at":   company.UpdatedAt,
+		},
+	}
+	return r.updateOne(ctx, company.ID, update)
+}
+
+func (r *MongoCompanyRepo) AddMember(ctx context.Context, id string, member entities.CompanyMember) error {
+	filter := bson.M{"_id": id, "members.user_id": bson.M{"$ne": member.UserID}}
+	update := bson.M{
+		"$push": bson.M{"members": member},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	return r.updateMembers(ctx, id, filter, update)
+}
+
+func (r *MongoCompanyRepo) SetMemberRole(ctx context.Context, id, userID, expectedRole, role string) error {
+	filter := memberFilter(id, userID, expectedRole, expectedRole == entities.CompanyRoleOwner && role != entities.CompanyRoleOwner)
+	update := bson.M{
+		"$set": bson.M{
+			"members.$[member].role": role,
+			"updated_at":             time.Now(),
+		},
+	}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"member.user_id": userID}},
+	})
+	return r.updateMembers(ctx, id, filter, update, opts)
+}
+
+func (r *MongoCompanyRepo) RemoveMember(ctx context.Context, id, userID, expectedRole string) error {
+	filter := memberFilter(id, userID, expectedRole, expectedRole == entities.CompanyRoleOwner)
+	update := bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": userID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+	return r.updateMembers(ctx, id, filter, update)
+}
+
+// memberFilter находит компанию, в которой у участника роль expectedRole;
+// с keepOwner — только если в команде есть и другой владелец
+func memberFilter(id, userID, expectedRole string, keepOwner bool) bson.M {
+	conditions := bson.A{
+		bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": userID, "role": expectedRole}}},
+	}
+	if keepOwner {
+		conditions = append(conditions, bson.M{"members": bson.M{"$elemMatch": bson.M{
+			"user_id": bson.M{"$ne": userID},
+			"role":    entities.CompanyRoleOwner,
+		}}})
+	}
+	return bson.M{"_id": id, "$and": conditions}
+}
+
+// updateMembers применяет изменение состава команды; если условие фильтра уже не выполняется,
+// возвращает ErrCompanyTeamConflict
+func (r *MongoCompanyRepo) updateMembers(ctx context.Context, id string, filter, update bson.M, opts ...*options.UpdateOptions) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid company ID format")
+	}
+
+	result, err := r.coll.UpdateOne(ctx, filter, update, opts...)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := r.coll.CountDocuments(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("company not found")
+	}
+	return repositories.ErrCompanyTeamConflict
+}
+
+func (r *MongoCompanyRepo) Delete(ctx context.Context, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid company ID format")
+	}
+
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errors.New("company not found")
+	}
+	return nil
+}
+
+func (r *MongoCompanyRepo) updateOne(ctx context.Context, id string, update bson.M) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid company ID format")
+	}
+
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("company not found")
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoConversationRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoConversationRepo(coll *mongo.Collection) repositories.ConversationRepository {
+	return &MongoConversationRepo{
+		coll: coll,
+	}
+}
+
+// EnsureConversationIndexes создает индексы коллекции переписок.
+// Уникальный индекс по (student_id, vacancy_id) гарантирует одну переписку на пару.
+func EnsureConversationIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "student_id", Value: 1}, {Key: "vacancy_id", Value: 1}},
+			Options: options.Index().SetName("student_id_vacancy_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "student_id", Value: 1}, {Key: "last_message_at", Value: -1}},
+			Options: options.Index().SetName("student_id_last_message_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "employer_id", Value: 1}, {Key: "last_message_at", Value: -1}},
+			Options: options.Index().SetName("employer_id_last_message_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "company_id", Value: 1}, {Key: "last_message_at", Value: -1}},
+			Options: options.Index().SetName("company_id_last_message_at"),
+		},
+	})
+	return err
+}
+
+func (r *MongoConversationRepo) FindOrCreate(ctx context.Context, conversation *entities.Conversation) (*entities.Conversation, error) {
+	now := time.Now()
+	filter := bson.M{"student_id": conversation.StudentID, "vacancy_id": conversation.VacancyID}
+	insert := bson.M{
+		"_id":             primitive.NewObjectID().Hex(),
+		"employer_id":     conversation.EmployerID,
+		"last_message_at": now,
+		"student_unread":  0,
+		"employer_unread": 0,
+		"created_at":      now,
+		"updated_at":      now,
+	}
+	if conversation.CompanyID != "" {
+		insert["company_id"] = conversation.CompanyID
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result entities.Conversation
+	err := r.coll.FindOneAndUpdate(ctx, filter, bson.M{"$setOnInsert": insert}, opts).Decode(&result)
+	// При параллельном создании upsert одного из запросов упирается в уникальный индекс — переписка уже есть
+	if mongo.IsDuplicateKeyError(err) {
+		err = r.coll.FindOne(ctx, filter).Decode(&result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (r *MongoConversationRepo) FindByID(ctx context.Context, id string) (*entities.Conversation, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.New("invalid conversation ID format")
+	}
+
+	var conversation entities.Conversation
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&conversation)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+func (r *MongoConversationRepo) FindByParticipant(ctx context.Context, participant repositories.ConversationParticipant, limit, offset int) ([]*entities.Conversation, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "last_message_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, participantFilter(participant), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	conversations := []*entities.Conversation{}
+	if err := cursor.All(ctx, &conversations); err != nil {
+		return nil, err
+	}
+	return conversations, nil
+}
+
+func (r *MongoConversationRepo) CountByParticipant(ctx context.Context, participant repositories.ConversationParticipant) (int64, error) {
+	return r.coll.CountDocuments(ctx, participantFilter(participant))
+}
+
+func (r *MongoConversationRepo) CountUnread(ctx context.Context, participant repositories.ConversationParticipant, side string) (int64, error) {
+	cursor, err := r.coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: participantFilter(participant)}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "unread": bson.M{"$sum": "$" + unreadField(side)}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Unread int64 `bson:"unread"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Unread, nil
+}
+
+func (r *MongoConversationRepo) AddMessage(ctx context.Context, id string, message *entities.Message, recipientSide string) error {
+	return r.updateOne(ctx, id, bson.M{
+		"$set": bson.M{
+			"last_message":    message,
+			"last_message_at": message.CreatedAt,
+			"updated_at":      time.Now(),
+		},
+		"$inc": bson.M{unreadField(recipientSide): 1},
+	})
+}
+
+func (r *MongoConversationRepo) SetLastMessage(ctx context.Context, id string, message *entities.Message) error {
+	update := bson.M{"$set": bson.M{"last_message": message, "updated_at": time.Now()}}
+	if message == nil {
+		update = bson.M{"$unset": bson.M{"last_message": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+	return r.updateOne(ctx, id, update)
+}
+
+func (r *MongoConversationRepo) AdjustUnread(ctx context.Context, id, side string, delta int) error {
+	field := unreadField(side)
+	return r.updateOne(ctx, id, mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		field: bson.M{"$max": bson.A{0, bson.M{"$add": bson.A{"$" + field, delta}}}},
+	}}}})
+}
+
+func (r *MongoConversationRepo) updateOne(ctx context.Context, id string, update interface{}) error {
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrConversationNotFound
+	}
+	return nil
+}
+
+// participantFilter отбирает переписки студента, вакансий автора без компании и вакансий компаний из списка
+func participantFilter(participant repositories.ConversationParticipant) bson.M {
+	or := bson.A{}
+	if participant.StudentID != "" {
+		or = append(or, bson.M{"student_id": participant.StudentID})
+	}
+	if participant.EmployerID != "" {
+		or = append(or, bson.M{"employer_id": participant.EmployerID, "company_id": nil})
+	}
+	if len(participant.CompanyIDs) > 0 {
+		or = append(or, bson.M{"company_id": bson.M{"$in": participant.CompanyIDs}})
+	}
+	if len(or) == 0 {
+		// Пользователь ни в чем не участвует: фильтр, которому не подходит ни один документ
+		return bson.M{"_id": bson.M{"$exists": false}}
+	}
+	return bson.M{"$or": or}
+}
+
+func unreadField(side string) string {
+	if side == entities.ConversationSideStudent {
+		return "student_unread"
+	}
+	return "employer_unread"
+}
@@ -0,0 +1,132 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoMessageRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoMessageRepo(coll *mongo.Collection) repositories.MessageRepository {
+	return &MongoMessageRepo{
+		coll: coll,
+	}
+}
+
+// EnsureMessageIndexes создает индексы для ленты сообщений и отметки прочитанных
+func EnsureMessageIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "conversation_id", Value: 1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("conversation_id_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "conversation_id", Value: 1}, {Key: "sender_side", Value: 1}, {Key: "read_at", Value: 1}},
+			Options: options.Index().SetName("conversation_id_sender_side_read_at"),
+		},
+	})
+	return err
+}
+
+func (r *MongoMessageRepo) Create(ctx context.Context, message *entities.Message) error {
+	message.ID = primitive.NewObjectID().Hex()
+	message.CreatedAt = time.Now()
+
+	_, err := r.coll.InsertOne(ctx, message)
+	return err
+}
+
+func (r *MongoMessageRepo) FindByID(ctx context.Context, id string) (*entities.Message, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.New("invalid message ID format")
+	}
+
+	var message entities.Message
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&message)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *MongoMessageRepo) FindByConversation(ctx context.Context, conversationID, before string, limit int) ([]*entities.Message, error) {
+	filter := bson.M{"conversation_id": conversationID}
+	if before != "" {
+		if !primitive.IsValidObjectID(before) {
+			return nil, errors.New("invalid message ID format")
+		}
+		// ObjectID в hex-представлении упорядочены по времени создания
+		filter["_id"] = bson.M{"$lt": before}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []*entities.Message{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *MongoMessageRepo) FindLast(ctx context.Context, conversationID string) (*entities.Message, error) {
+	var message entities.Message
+	opts := options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})
+	err := r.coll.FindOne(ctx, bson.M{"conversation_id": conversationID, "deleted_at": nil}, opts).Decode(&message)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *MongoMessageRepo) Update(ctx context.Context, message *entities.Message) error {
+	result, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": message.ID},
+		bson.M{"$set": bson.M{
+			"body":        message.Body,
+			"attachments": message.Attachments,
+			"edited_at":   message.EditedAt,
+			"deleted_at":  message.DeletedAt,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrMessageNotFound
+	}
+	return nil
+}
+
+func (r *MongoMessageRepo) MarkRead(ctx context.Context, conversationID, senderSide string, at time.Time) (int64, error) {
+	result, err := r.coll.UpdateMany(ctx,
+		bson.M{"conversation_id": conversationID, "sender_side": senderSide, "read_at": nil},
+		bson.M{"$set": bson.M{"read_at": at}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,148 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoNotificationRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoNotificationRepo(coll *mongo.Collection) repositories.NotificationRepository {
+	return &MongoNotificationRepo{
+		coll: coll,
+	}
+}
+
+// EnsureNotificationIndexes создает индекс для выдачи уведомлений пользователя непрочитанными первыми
+func EnsureNotificationIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "read", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("user_id_read_created_at"),
+	})
+	return err
+}
+
+func (r *MongoNotificationRepo) CreateMany(ctx context.Context, notifications []*entities.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, len(notifications))
+	for i, notification := range notifications {
+		notification.ID = primitive.NewObjectID().Hex()
+		notification.CreatedAt = now
+		docs[i] = notification
+	}
+
+	_, err := r.coll.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *MongoNotificationRepo) FindByUser(ctx context.Context, userID string, limit, offset int) ([]*entities.Notification, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "read", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*entities.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *MongoNotificationRepo) FindAfter(ctx context.Context, userID, afterID string, limit int) ([]*entities.Notification, error) {
+	if !primitive.IsValidObjectID(afterID) {
+		return nil, errors.New("invalid notification ID format")
+	}
+
+	// ObjectID в hex-представлении упорядочены по времени создания
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, bson.M{"user_id": userID, "_id": bson.M{"$gt": afterID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []*entities.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *MongoNotificationRepo) CountByUser(ctx context.Context, userID string) (int64, error) {
+	return r.coll.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *MongoNotificationRepo) CountUnread(ctx context.Context, userID string) (int64, error) {
+	return r.coll.CountDocuments(ctx, bson.M{"user_id": userID, "read": false})
+}
+
+func (r *MongoNotificationRepo) MarkRead(ctx context.Context, userID, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid notification ID format")
+	}
+
+	result, err := r.coll.UpdateOne(ctx,
+		bson.M{"_id": id, "user_id": userID},
+		// $ifNull сохраняет время первого прочтения при повторной отметке
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{
+			"read":    true,
+			"read_at": bson.M{"$ifNull": bson.A{"$read_at", time.Now()}},
+		}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *MongoNotificationRepo) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	result, err := r.coll.UpdateMany(ctx,
+		bson.M{"user_id": userID, "read": false},
+		bson.M{"$set": bson.M{"read": true, "read_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (r *MongoNotificationRepo) Delete(ctx context.Context, userID, id string) error {
+	if !primitive.IsValidObjectID(id) {
+		return errors.New("invalid notification ID format")
+	}
+
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type rateLimitDoc struct {
+	ID      string    `bson:"_id"`
+	Count   int       `bson:"count"`
+	ResetAt time.Time `bson:"reset_at"`
+}
+
+type MongoRateLimitRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoRateLimitRepo(coll *mongo.Collection) repositories.RateLimitRepository {
+	return &MongoRateLimitRepo{
+		coll: coll,
+	}
+}
+
+// EnsureRateLimitIndexes создает TTL-индекс, удаляющий истекшие счетчики
+func EnsureRateLimitIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "reset_at", Value: 1}},
+		Options: options.Index().SetName("reset_at_ttl").SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (r *MongoRateLimitRepo) Hit(ctx context.Context, key string, window time.Duration) (int, time.Time, error) {
+	now := time.Now()
+	windowActive := bson.M{"$gt": bson.A{"$reset_at", now}}
+
+	// Обновление конвейером выполняется атомарно: истекшее окно начинается заново с count = 1
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"count":    bson.M{"$cond": bson.A{windowActive, bson.M{"$add": bson.A{"$count", 1}}, 1}},
+			"reset_at": bson.M{"$cond": bson.A{windowActive, "$reset_at", now.Add(window)}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc rateLimitDoc
+	if err := r.coll.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&doc); err != nil {
+		return 0, time.Time{}, err
+	}
+	return doc.Count, doc.ResetAt, nil
+}
+
+func (r *MongoRateLimitRepo) Get(ctx context.Context, key string) (int, time.Time, error) {
+	var doc rateLimitDoc
+	err := r.coll.FindOne(ctx, bson.M{"_id": key}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, time.Time{}, nil
+		}
+		return 0, time.Time{}, err
+	}
+	if !time.Now().Before(doc.ResetAt) {
+		return 0, time.Time{}, nil
+	}
+	return doc.Count, doc.ResetAt, nil
+}
+
+func (r *MongoRateLimitRepo) Reset(ctx context.Context, key string) error {
+	_, err := r.coll.DeleteOne(ctx, bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,104 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoRefreshTokenRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoRefreshTokenRepo(coll *mongo.Collection) repositories.RefreshTokenRepository {
+	return &MongoRefreshTokenRepo{
+		coll: coll,
+	}
+}
+
+// EnsureRefreshTokenIndexes создает индексы коллекции refresh-токенов.
+// TTL-индекс удаляет записи после истечения срока действия.
+func EnsureRefreshTokenIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "token_hash", Value: 1}},
+			Options: options.Index().SetName("token_hash").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "family_id", Value: 1}},
+			Options: options.Index().SetName("family_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}},
+			Options: options.Index().SetName("user_id"),
+		},
+		{
+			Keys:    bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+		},
+	})
+	return err
+}
+
+func (r *MongoRefreshTokenRepo) Create(ctx context.Context, token *entities.RefreshToken) error {
+	token.ID = primitive.NewObjectID().Hex()
+	token.CreatedAt = time.Now()
+
+	_, err := r.coll.InsertOne(ctx, token)
+	return err
+}
+
+func (r *MongoRefreshTokenRepo) FindByHash(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := r.coll.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *MongoRefreshTokenRepo) Revoke(ctx context.Context, id, replacedBy string) error {
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at":  time.Now(),
+			"replaced_by": replacedBy,
+		},
+	}
+
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id, "revoked_at": nil}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrRefreshTokenRevoked
+	}
+	return nil
+}
+
+func (r *MongoRefreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.revokeMany(ctx, bson.M{"family_id": familyID, "revoked_at": nil})
+}
+
+func (r *MongoRefreshTokenRepo) RevokeAllForUser(ctx context.Context, userID string) error {
+	return r.revokeMany(ctx, bson.M{"user_id": userID, "revoked_at": nil})
+}
+
+func (r *MongoRefreshTokenRepo) revokeMany(ctx context.Context, filter bson.M) error {
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": time.Now(),
+		},
+	}
+	_, err := r.coll.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,165 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoSavedSearchRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoSavedSearchRepo(coll *mongo.Collection) repositories.SavedSearchRepository {
+	return &MongoSavedSearchRepo{
+		coll: coll,
+	}
+}
+
+// EnsureSavedSearchIndexes создает индексы для списка поисков пользователя, подбора поисков под вакансию и отправки сводок
+func EnsureSavedSearchIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("user_id_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "type", Value: 1}, {Key: "format", Value: 1}, {Key: "location", Value: 1}},
+			Options: options.Index().SetName("type_format_location"),
+		},
+		{
+			Keys:    bson.D{{Key: "frequency", Value: 1}, {Key: "last_notified_at", Value: 1}},
+			Options: options.Index().SetName("frequency_last_notified_at"),
+		},
+	})
+	return err
+}
+
+func (r *MongoSavedSearchRepo) Create(ctx context.Context, search *entities.SavedSearch) error {
+	search.ID = primitive.NewObjectID().Hex()
+	search.CreatedAt = time.Now()
+	search.UpdatedAt = search.CreatedAt
+	search.LastNotifiedAt = search.CreatedAt
+	if search.PendingVacancyIDs == nil {
+		search.PendingVacancyIDs = []string{}
+	}
+
+	_, err := r.coll.InsertOne(ctx, search)
+	return err
+}
+
+func (r *MongoSavedSearchRepo) FindByID(ctx context.Context, id string) (*entities.SavedSearch, error) {
+	if !primitive.IsValidObjectID(id) {
+		return nil, errors.New("invalid saved search ID format")
+	}
+
+	var search entities.SavedSearch
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&search)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *MongoSavedSearchRepo) FindByUser(ctx context.Context, userID string) ([]*entities.SavedSearch, error) {
+	return r.find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+func (r *MongoSavedSearchRepo) CountByUser(ctx context.Context, userID string) (int64, error) {
+	return r.coll.CountDocuments(ctx, bson.M{"user_id": userID})
+}
+
+func (r *MongoSavedSearchRepo) FindMatchCandidates(ctx context.Context, vacancy *entities.Vacancy) ([]*entities.SavedSearch, error) {
+	filter := bson.M{
+		"type":     bson.M{"$in": bson.A{"", vacancy.Type}},
+		"format":   bson.M{"$in": bson.A{"", vacancy.Format}},
+		"location": bson.M{"$in": bson.A{"", vacancy.Location}},
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *MongoSavedSearchRepo) FindDigestsDue(ctx context.Context, frequency string, notifiedBefore time.Time) ([]*entities.SavedSearch, error) {
+	filter := bson.M{
+		"frequency":             frequency,
+		"last_notified_at":      bson.M{"$lte": notifiedBefore},
+		"pending_vacancy_ids.0": bson.M{"$exists": true},
+	}
+	return r.find(ctx, filter, options.Find())
+}
+
+func (r *MongoSavedSearchRepo) Update(ctx context.Context, search *entities.SavedSearch) error {
+	search.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":       search.Name,
+			"keyword":    search.Keyword,
+			"type":       search.Type,
+			"format":     search.Format,
+			"location":   search.Location,
+			"min_salary": search.MinSalary,
+			"skills":     search.Skills,
+			"frequency":  search.Frequency,
+			"updated_at": search.UpdatedAt,
+		},
+	}
+	return r.updateOne(ctx, search.ID, update)
+}
+
+func (r *MongoSavedSearchRepo) AddPending(ctx context.Context, id, vacancyID string) error {
+	return r.updateOne(ctx, id, bson.M{"$addToSet": bson.M{"pending_vacancy_ids": vacancyID}})
+}
+
+func (r *MongoSavedSearchRepo) MarkNotified(ctx context.Context, id string, vacancyIDs []string, at time.Time) error {
+	update := bson.M{
+		"$set":  bson.M{"last_notified_at": at},
+		"$pull": bson.M{"pending_vacancy_ids": bson.M{"$in": vacancyIDs}},
+	}
+	return r.updateOne(ctx, id, update)
+}
+
+func (r *MongoSavedSearchRepo) Delete(ctx context.Context, id string) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *MongoSavedSearchRepo) updateOne(ctx context.Context, id string, update bson.M) error {
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+func (r *MongoSavedSearchRepo) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*entities.SavedSearch, error) {
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	searches := []*entities.SavedSearch{}
+	if err := cursor.All(ctx, &searches); err != nil {
+		return nil, err
+	}
+	return searches, nil
+}
@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoStudentProfileRepo struct {
+	coll *mongo.Collection
+}
+
+func NewMongoStudentProfileRepo(coll *mongo.Collection) repositories.StudentProfileRepository {
+	return &MongoStudentProfileRepo{
+		coll: coll,
+	}
+}
+
+// EnsureStudentProfileIndexes создает индексы для подбора студентов по навыкам и году выпуска
+func EnsureStudentProfileIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "skills", Value: 1}},
+			Options: options.Index().SetName("skills"),
+		},
+		{
+			Keys:    bson.D{{Key: "graduation_year", Value: 1}},
+			Options: options.Index().SetName("graduation_year"),
+		},
+		{
+			Keys:    bson.D{{Key: "discoverable", Value: 1}, {Key: "skills", Value: 1}},
+			Options: options.Index().SetName("discoverable_skills"),
+		},
+	})
+	return err
+}
+
+func (r *MongoStudentProfileRepo) Create(ctx context.Context, profile *entities.StudentProfile) error {
+	profile.CreatedAt = time.Now()
+	profile.UpdatedAt = profile.CreatedAt
+
+	_, err := r.coll.InsertOne(ctx, profile)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrStudentProfileExists
+	}
+	return err
+}
+
+func (r *MongoStudentProfileRepo) FindByUserID(ctx context.Context, userID string) (*entities.StudentProfile, error) {
+	var profile entities.StudentProfile
+	err := r.coll.FindOne(ctx, bson.M{"_id": userID}).Decode(&profile)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *MongoStudentProfileRepo) FindDiscoverable(ctx context.Context, skills []string, limit int) ([]*entities.StudentProfile, error) {
+	filter := bson.M{"discoverable": true}
+	if len(skills) > 0 {
+		filter["skills"] = bson.M{"$in": skills}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	profiles := []*entities.StudentProfile{}
+	if err := cursor.All(ctx, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (r *MongoStudentProfileRepo) Update(ctx context.Context, profile *entities.StudentProfile) error {
+	profile.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"university":          profile.University,
+			"faculty":             profile.Faculty,
+			"graduation_year":     profile.GraduationYear,
+			"gpa":                 profile.GPA,
+			"skills":              profile.Skills,
+			"languages":           profile.Languages,
+			"experience":          profile.Experience,
+			"projects":            profile.Projects,
+			"desired_salary":      profile.DesiredSalary,
+			"preferred_types":     profile.PreferredTypes,
+			"preferred_formats":   profile.PreferredFormats,
+			"preferred_locations": profile.PreferredLocations,
+			"discoverable":        profile.Discoverable,
+			"updated_at":          profile.UpdatedAt,
+		},
+	}
+
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": profile.UserID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repositories.ErrStudentProfileNotFound
+	}
+	return nil
+}
+
+func (r *MongoStudentProfileRepo) Delete(ctx context.Context, userID string) error {
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return repositories.ErrStudentProfileNotFound
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// legacyUserFields поля, которые остались от прежних схем пользователя:
+// entities.User без bson-тегов ("id", "passwordhash", "isverified", "createdat")
+// и models.User ("password" и ObjectID в "_id")
+var legacyUserFields = []string{"id", "passwordhash", "isverified", "createdat", "password"}
+
+// MigrateUserDocuments переписывает документы пользователей старых форматов в текущую схему entities.User.
+// Возвращает количество переписанных документов. Повторный запуск безопасен.
+func MigrateUserDocuments(ctx context.Context, coll *mongo.Collection) (int, error) {
+	conditions := bson.A{bson.M{"_id": bson.M{"$type": "objectId"}}}
+	for _, field := range legacyUserFields {
+		conditions = append(conditions, bson.M{field: bson.M{"$exists": true}})
+	}
+
+	cursor, err := coll.Find(ctx, bson.M{"$or": conditions})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	migrated := 0
+	for cursor.Next(ctx) {
+		var raw bson.M
+		if err := cursor.Decode(&raw); err != nil {
+			return migrated, err
+		}
+
+		user := legacyUserToEntity(raw)
+		if user.ID == "" {
+			continue
+		}
+
+		if raw["_id"] != user.ID {
+			if err := moveUserDocument(ctx, coll, raw["_id"], user); err != nil {
+				return migrated, fmt.Errorf("migrate user %s: %w", user.ID, err)
+			}
+		} else if _, err := coll.ReplaceOne(ctx, bson.M{"_id": user.ID}, user); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+	return migrated, cursor.Err()
+}
+
+// moveUserDocument переносит пользователя под новый _id: _id нельзя изменить на месте,
+// поэтому сначала вставляется документ с новым ключом, затем удаляется старый.
+// Старый документ удаляется, только когда документ с новым _id точно есть в коллекции.
+func moveUserDocument(ctx context.Context, coll *mongo.Collection, oldID interface{}, user *entities.User) error {
+	// Документ с новым _id уже есть: предыдущий запуск прервался после вставки
+	err := coll.FindOne(ctx, bson.M{"_id": user.ID}).Err()
+	if err == nil {
+		_, err = coll.DeleteOne(ctx, bson.M{"_id": oldID})
+		return err
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	// Email и телефон старого документа временно переносятся в запасные поля,
+	// иначе новый документ столкнется с ним же в индексах email_unique и phone_unique
+	contacts := bson.M{}
+	if user.Email != "" {
+		contacts["email"] = user.Email
+	}
+	if user.Phone != "" {
+		contacts["phone"] = user.Phone
+	}
+	if len(contacts) > 0 {
+		if _, err := coll.UpdateOne(ctx, bson.M{"_id": oldID}, bson.M{
+			"$set":   bson.M{"legacy_contacts": contacts},
+			"$unset": bson.M{"email": "", "phone": ""},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if _, err := coll.InsertOne(ctx, user); err != nil {
+		// Email или телефон занят другим пользователем: старый документ остается как был
+		if len(contacts) > 0 {
+			if _, restoreErr := coll.UpdateOne(ctx, bson.M{"_id": oldID}, bson.M{
+				"$set":   contacts,
+				"$unset": bson.M{"legacy_contacts": ""},
+			}); restoreErr != nil {
+				return fmt.Errorf("%w (restore contacts: %v)", err, restoreErr)
+			}
+		}
+		return err
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.M{"_id": oldID})
+	return err
+}
+
+func legacyUserToEntity(raw bson.M) *entities.User {
+	// Контакты могли остаться в legacy_contacts, если перенос прервался после их сохранения
+	contacts, _ := raw["legacy_contacts"].(bson.M)
+	user := &entities.User{
+		Email:        firstNonEmpty(stringField(raw, "email"), stringField(contacts, "email")),
+		Phone:        firstNonEmpty(stringField(raw, "phone"), stringField(contacts, "phone")),
+		PasswordHash: stringField(raw, "password_hash", "passwordhash"),
+		Name:         stringField(raw, "name"),
+		Role:         stringField(raw, "role"),
+	}
+
+	user.ID = stringField(raw, "id")
+	if user.ID == "" {
+		switch id := raw["_id"].(type) {
+		case string:
+			user.ID = id
+		case primitive.ObjectID:
+			user.ID = id.Hex()
+		}
+	}
+
+	// В models.User поле "password" переносится, только если в нем bcrypt-хеш, а не открытый пароль
+	if password := stringField(raw, "password"); user.PasswordHash == "" && strings.HasPrefix(password, "$2") {
+		user.PasswordHash = password
+	}
+
+	for _, key := range []string{"is_verified", "isverified"} {
+		if verified, ok := raw[key].(bool); ok {
+			user.IsVerified = verified
+			break
+		}
+	}
+
+	for _, key := range []string{"created_at", "createdat"} {
+		if createdAt, ok := raw[key].(primitive.DateTime); ok {
+			user.CreatedAt = createdAt.Time()
+			break
+		}
+	}
+	if user.CreatedAt.IsZero() {
+		if oid, ok := raw["_id"].(primitive.ObjectID); ok {
+			user.CreatedAt = oid.Timestamp()
+		} else {
+			user.CreatedAt = time.Now()
+		}
+	}
+
+	return user
+}
+
+// stringField возвращает первое непустое строковое значение из перечисленных полей
+func stringField(raw bson.M, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := raw[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -3,11 +3,13 @@ package mongo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
 	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoUserRepo struct {
@@ -20,8 +22,29 @@ func NewMongoUserRepo(coll *mongo.Collection) repositories.UserRepository {
 	}
 }
 
+// EnsureUserIndexes создает уникальные индексы на email и телефон.
+// Пустые значения в индекс не попадают, поэтому пользователь может иметь только email или только телефон.
+func EnsureUserIndexes(ctx context.Context, coll *mongo.Collection) error {
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "email", Value: 1}},
+			Options: options.Index().SetName("email_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"email": bson.M{"$gt": ""}}),
+		},
+		{
+			Keys: bson.D{{Key: "phone", Value: 1}},
+			Options: options.Index().SetName("phone_unique").SetUnique(true).
+				SetPartialFilterExpression(bson.M{"phone": bson.M{"$gt": ""}}),
+		},
+	})
+	return err
+}
+
 func (r *MongoUserRepo) Create(ctx context.Context, user *entities.User) error {
 	_, err := r.coll.InsertOne(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrUserExists
+	}
 	return err
 }
 
@@ -62,7 +85,35 @@ func (r *MongoUserRepo) FindByID(ctx context.Context, id string) (*entities.User
 }
 
 func (r *MongoUserRepo) Update(ctx context.Context, user *entities.User) error {
-	update := bson.M{"$set": user}
+	// Обновляются только изменяемые поля, идентификатор и дата создания остаются прежними
+	update := bson.M{
+		"$set": bson.M{
+			"email":               user.Email,
+			"phone":               user.Phone,
+			"password_hash":       user.PasswordHash,
+			"name":                user.Name,
+			"role":                user.Role,
+			"is_verified":         user.IsVerified,
+			"avatar_url":          user.AvatarURL,
+			"contact_preferences": user.ContactPreferences,
+		},
+	}
 	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": user.ID}, update)
+	if mongo.IsDuplicateKeyError(err) {
+		return repositories.ErrUserExists
+	}
 	return err
 }
+
+func (r *MongoUserRepo) DeleteUnverifiedBefore(ctx context.Context, before time.Time) (int64, error) {
+	filter := bson.M{
+		"is_verified":   false,
+		"password_hash": "",
+		"created_at":    bson.M{"$lt": before},
+	}
+	result, err := r.coll.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// vacancySortSpec описывает поле сортировки списка вакансий
+type vacancySortSpec struct {
+	field     string
+	direction int
+}
+
+var vacancySorts = map[string]vacancySortSpec{
+	repositories.VacancySortNewest:   {field: "created_at", direction: -1},
+	repositories.VacancySortDeadline: {field: "deadline_sort", direction: 1},
+	repositories.VacancySortSalary:   {field: "salary_sort", direction: -1},
+	repositories.VacancySortViews:    {field: "views_count", direction: -1},
+}
+
+// vacancyNoDeadline ключ сортировки вакансий без дедлайна: они идут после всех вакансий с дедлайном
+var vacancyNoDeadline = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// deadlineSortKey ключ сортировки по дедлайну, совпадает с полем deadline_sort в FindAll
+func deadlineSortKey(vacancy *entities.Vacancy) time.Time {
+	if vacancy.Deadline.IsZero() {
+		return vacancyNoDeadline
+	}
+	return vacancy.Deadline
+}
+
+// vacancyCursor позиция последнего элемента страницы: значение поля сортировки и ID.
+// Клиенту отдается в виде непрозрачной base64-строки.
+type vacancyCursor struct {
+	Sort string     `json:"s"`
+	Time *time.Time `json:"t,omitempty"`
+	Num  *int64     `json:"n,omitempty"`
+	ID   string     `json:"id"`
+}
+
+func newVacancyCursor(sort string, vacancy *entities.Vacancy) vacancyCursor {
+	cursor := vacancyCursor{Sort: sort, ID: vacancy.ID}
+	switch sort {
+	case repositories.VacancySortNewest:
+		cursor.Time = &vacancy.CreatedAt
+	case repositories.VacancySortDeadline:
+		deadline := deadlineSortKey(vacancy)
+		cursor.Time = &deadline
+	case repositories.VacancySortSalary:
+		n := int64(vacancy.MaxSalary())
+		cursor.Num = &n
+	case repositories.VacancySortViews:
+		n := int64(vacancy.ViewsCount)
+		cursor.Num = &n
+	}
+	return cursor
+}
+
+func (c vacancyCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeVacancyCursor(raw, sort string) (*vacancyCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, repositories.ErrInvalidCursor
+	}
+
+	var cursor vacancyCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, repositories.ErrInvalidCursor
+	}
+	if cursor.Sort != sort || cursor.ID == "" || (cursor.Time == nil && cursor.Num == nil) {
+		return nil, repositories.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// after возвращает условие выборки элементов, следующих за курсором в порядке сортировки
+func (c *vacancyCursor) after(spec vacancySortSpec) bson.M {
+	var value interface{}
+	if c.Time != nil {
+		value = *c.Time
+	} else {
+		value = *c.Num
+	}
+
+	op := "$lt"
+	if spec.direction > 0 {
+		op = "$gt"
+	}
+
+	return bson.M{
+		"$or": bson.A{
+			bson.M{spec.field: bson.M{op: value}},
+			bson.M{spec.field: value, "_id": bson.M{op: c.ID}},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
@@ -10,6 +11,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoVacancyRepo struct {
@@ -22,10 +24,86 @@ func NewMongoVacancyRepo(coll *mongo.Collection) repositories.VacancyRepository
 	}
 }
 
+// NewMongoVacancySearcher возвращает полнотекстовый поиск по коллекции вакансий.
+// Требует текстового индекса, создаваемого EnsureVacancyIndexes.
+func NewMongoVacancySearcher(coll *mongo.Collection) repositories.VacancySearcher {
+	return &MongoVacancyRepo{
+		coll: coll,
+	}
+}
+
+// EnsureVacancyIndexes создает индексы коллекции вакансий
+func EnsureVacancyIndexes(ctx context.Context, coll *mongo.Collection) error {
+	// Поля сортируются, чтобы спецификация индекса не менялась между запусками
+	fields := make([]string, 0, len(repositories.VacancySearchWeights))
+	for field := range repositories.VacancySearchWeights {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	textKeys := bson.D{}
+	textWeights := bson.D{}
+	for _, field := range fields {
+		textKeys = append(textKeys, bson.E{Key: field, Value: "text"})
+		textWeights = append(textWeights, bson.E{Key: field, Value: repositories.VacancySearchWeights[field]})
+	}
+
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			// Текстовый индекс с русской морфологией для полнотекстового поиска
+			Keys: textKeys,
+			Options: options.Index().
+				SetName("vacancy_text").
+				SetWeights(textWeights).
+				SetDefaultLanguage("russian"),
+		},
+		{
+			Keys:    bson.D{{Key: "employer_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("employer_id_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "company_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("company_id_status_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("status_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "deadline", Value: 1}, {Key: "_id", Value: 1}},
+			Options: options.Index().SetName("status_deadline"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "views_count", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("status_views_count"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "type", Value: 1}, {Key: "format", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("status_type_format_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "location", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("location_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "skills", Value: 1}},
+			Options: options.Index().SetName("skills"),
+		},
+		{
+			Keys:    bson.D{{Key: "salary_fixed", Value: -1}},
+			Options: options.Index().SetName("salary_fixed"),
+		},
+		{
+			Keys:    bson.D{{Key: "salary_to", Value: -1}},
+			Options: options.Index().SetName("salary_to"),
+		},
+	})
+	return err
+}
+
 func (r *MongoVacancyRepo) Create(ctx context.Context, vacancy *entities.Vacancy) error {
-	// Генерируем ObjectID для новой вакансии
-	objectID := primitive.NewObjectID()
-	vacancy.ID = objectID.Hex()
+	// Генерируем ObjectID для новой вакансии; _id хранится в виде hex-строки
+	vacancy.ID = primitive.NewObjectID().Hex()
 	vacancy.CreatedAt = time.Now()
 	vacancy.UpdatedAt = time.Now()
 	
@@ -38,13 +116,12 @@ func (r *MongoVacancyRepo) Create(ctx context.Context, vacancy *entities.Vacancy
 }
 
 func (r *MongoVacancyRepo) FindByID(ctx context.Context, id string) (*entities.Vacancy, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
+	if !primitive.IsValidObjectID(id) {
 		return nil, errors.New("invalid vacancy ID format")
 	}
 
 	var vacancy entities.Vacancy
-	err = r.coll.FindOne(ctx, bson.M{"_id": objectID}).Decode(&vacancy)
+	err := r.coll.FindOne(ctx, bson.M{"_id": id}).Decode(&vacancy)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return nil, nil
@@ -54,19 +131,203 @@ func (r *MongoVacancyRepo) FindByID(ctx context.Context, id string) (*entities.V
 	return &vacancy, nil
 }
 
-func (r *MongoVacancyRepo) FindAll(ctx context.Context, status string) ([]*entities.Vacancy, error) {
+func (r *MongoVacancyRepo) FindByIDs(ctx context.Context, ids []string) (map[string]*entities.Vacancy, error) {
+	vacancies := make(map[string]*entities.Vacancy, len(ids))
+	if len(ids) == 0 {
+		return vacancies, nil
+	}
+
+	cursor, err := r.coll.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var vacancy entities.Vacancy
+		if err := cursor.Decode(&vacancy); err != nil {
+			return nil, err
+		}
+		vacancies[vacancy.ID] = &vacancy
+	}
+	return vacancies, cursor.Err()
+}
+
+func (r *MongoVacancyRepo) FindAll(ctx context.Context, vacancyFilter repositories.VacancyFilter, page repositories.VacancyPageRequest) (*repositories.VacancyPage, error) {
+	filter := buildVacancyFilter(vacancyFilter, time.Now())
+
+	spec, ok := vacancySorts[page.Sort]
+	if !ok {
+		spec = vacancySorts[repositories.VacancySortNewest]
+		page.Sort = repositories.VacancySortNewest
+	}
+
+	total, err := r.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		// salary_sort — верхняя граница зарплаты, совпадает с entities.Vacancy.MaxSalary;
+		// deadline_sort — дедлайн, а у вакансий без дедлайна (нулевое время) — дата после всех дедлайнов
+		{{Key: "$addFields", Value: bson.M{
+			"salary_sort": bson.M{"$ifNull": bson.A{"$salary_fixed", bson.M{"$ifNull": bson.A{"$salary_to", 0}}}},
+			"deadline_sort": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$deadline", time.Time{}}}, time.Time{}}},
+				"$deadline",
+				vacancyNoDeadline,
+			}},
+		}}},
+	}
+
+	if page.Cursor != "" {
+		cursor, err := decodeVacancyCursor(page.Cursor, page.Sort)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: cursor.after(spec)}})
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: spec.field, Value: spec.direction}, {Key: "_id", Value: spec.direction}}}})
+	if page.Cursor == "" && page.Offset > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: int64(page.Offset)}})
+	}
+
+	// Запрашиваем на один элемент больше, чтобы понять, есть ли следующая страница
+	pipeline = append(pipeline,
+		bson.D{{Key: "$limit", Value: int64(page.Limit + 1)}},
+		bson.D{{Key: "$project", Value: bson.M{"salary_sort": 0, "deadline_sort": 0}}},
+	)
+
+	cursor, err := r.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	vacancies := []*entities.Vacancy{}
+	if err := cursor.All(ctx, &vacancies); err != nil {
+		return nil, err
+	}
+
+	result := &repositories.VacancyPage{Total: total}
+	if len(vacancies) > page.Limit {
+		vacancies = vacancies[:page.Limit]
+		result.NextCursor = newVacancyCursor(page.Sort, vacancies[len(vacancies)-1]).encode()
+	}
+	result.Items = vacancies
+
+	return result, nil
+}
+
+func (r *MongoVacancyRepo) Search(ctx context.Context, query string, vacancyFilter repositories.VacancyFilter, limit, offset int) (*repositories.VacancySearchPage, error) {
+	filter := buildVacancyFilter(vacancyFilter, time.Now())
+	filter["$text"] = bson.M{"$search": query, "$language": "russian"}
+
+	total, err := r.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		entities.Vacancy `bson:",inline"`
+		Score            float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	page := &repositories.VacancySearchPage{
+		Items: make([]repositories.VacancySearchResult, 0, len(docs)),
+		Total: total,
+	}
+	for i := range docs {
+		page.Items = append(page.Items, repositories.VacancySearchResult{
+			Vacancy: &docs[i].Vacancy,
+			Score:   docs[i].Score,
+		})
+	}
+	return page, nil
+}
+
+// buildVacancyFilter преобразует VacancyFilter в условие выборки MongoDB
+func buildVacancyFilter(f repositories.VacancyFilter, now time.Time) bson.M {
 	filter := bson.M{}
-	if status != "" {
-		filter["status"] = status
+	var and bson.A
+
+	if f.CompanyID != "" {
+		filter["company_id"] = f.CompanyID
+	}
+	if f.Status != "" {
+		filter["status"] = f.Status
+	}
+	if f.Type != "" {
+		filter["type"] = f.Type
+	}
+	if f.Format != "" {
+		filter["format"] = f.Format
+	}
+	if f.Location != "" {
+		filter["location"] = f.Location
+	}
+	if len(f.Skills) > 0 {
+		if f.SkillsMatchAll {
+			filter["skills"] = bson.M{"$all": f.Skills}
+		} else {
+			filter["skills"] = bson.M{"$in": f.Skills}
+		}
+	}
+	if f.MinSalary != nil {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"salary_fixed": bson.M{"$gte": *f.MinSalary}},
+			bson.M{"salary_to": bson.M{"$gte": *f.MinSalary}},
+		}})
+	}
+	if f.NotExpired {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"deadline": bson.M{"$gte": now}},
+			bson.M{"deadline": time.Time{}},
+			bson.M{"deadline": nil},
+		}})
+	}
+
+	if len(and) > 0 {
+		filter["$and"] = and
+	}
+	return filter
+}
+
+func (r *MongoVacancyRepo) FindByEmployer(ctx context.Context, employerID string, companyIDs []string) ([]*entities.Vacancy, error) {
+	filter := bson.M{"employer_id": employerID}
+	if len(companyIDs) > 0 {
+		filter = bson.M{"$or": bson.A{
+			filter,
+			bson.M{"company_id": bson.M{"$in": companyIDs}},
+		}}
 	}
 
-	cursor, err := r.coll.Find(ctx, filter)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.coll.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var vacancies []*entities.Vacancy
+	vacancies := []*entities.Vacancy{}
 	if err := cursor.All(ctx, &vacancies); err != nil {
 		return nil, err
 	}
@@ -75,8 +336,7 @@ func (r *MongoVacancyRepo) FindAll(ctx context.Context, status string) ([]*entit
 }
 
 func (r *MongoVacancyRepo) Update(ctx context.Context, vacancy *entities.Vacancy) error {
-	objectID, err := primitive.ObjectIDFromHex(vacancy.ID)
-	if err != nil {
+	if !primitive.IsValidObjectID(vacancy.ID) {
 		return errors.New("invalid vacancy ID format")
 	}
 
@@ -84,6 +344,7 @@ func (r *MongoVacancyRepo) Update(ctx context.Context, vacancy *entities.Vacancy
 	
 	update := bson.M{
 		"$set": bson.M{
+			"company_id":       vacancy.CompanyID,
 			"title":            vacancy.Title,
 			"type":             vacancy.Type,
 			"format":           vacancy.Format,
@@ -97,13 +358,12 @@ func (r *MongoVacancyRepo) Update(ctx context.Context, vacancy *entities.Vacancy
 			"responsibilities": vacancy.Responsibilities,
 			"requirements":     vacancy.Requirements,
 			"benefits":         vacancy.Benefits,
-			"status":           vacancy.Status,
 			"deadline":         vacancy.Deadline,
 			"updated_at":       vacancy.UpdatedAt,
 		},
 	}
 
-	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": vacancy.ID}, update)
 	if err != nil {
 		return err
 	}
@@ -116,8 +376,7 @@ func (r *MongoVacancyRepo) Update(ctx context.Context, vacancy *entities.Vacancy
 }
 
 func (r *MongoVacancyRepo) UpdateStatus(ctx context.Context, id string, status string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
+	if !primitive.IsValidObjectID(id) {
 		return errors.New("invalid vacancy ID format")
 	}
 
@@ -128,7 +387,7 @@ func (r *MongoVacancyRepo) UpdateStatus(ctx context.Context, id string, status s
 		},
 	}
 
-	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
 	if err != nil {
 		return err
 	}
@@ -141,12 +400,11 @@ func (r *MongoVacancyRepo) UpdateStatus(ctx context.Context, id string, status s
 }
 
 func (r *MongoVacancyRepo) Delete(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
+	if !primitive.IsValidObjectID(id) {
 		return errors.New("invalid vacancy ID format")
 	}
 
-	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": objectID})
+	result, err := r.coll.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
 		return err
 	}
@@ -159,8 +417,7 @@ func (r *MongoVacancyRepo) Delete(ctx context.Context, id string) error {
 }
 
 func (r *MongoVacancyRepo) IncrementViews(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
+	if !primitive.IsValidObjectID(id) {
 		return errors.New("invalid vacancy ID format")
 	}
 
@@ -170,13 +427,12 @@ func (r *MongoVacancyRepo) IncrementViews(ctx context.Context, id string) error
 		},
 	}
 
-	_, err = r.coll.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
 	return err
 }
 
 func (r *MongoVacancyRepo) IncrementResponses(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
+	if !primitive.IsValidObjectID(id) {
 		return errors.New("invalid vacancy ID format")
 	}
 
@@ -186,6 +442,6 @@ func (r *MongoVacancyRepo) IncrementResponses(ctx context.Context, id string) er
 		},
 	}
 
-	_, err = r.coll.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	_, err := r.coll.UpdateOne(ctx, bson.M{"_id": id}, update)
 	return err
 }
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+)
+
+// AlertNotifiers доставляет оповещение в основной канал и дополнительные каналы.
+// Успех определяется основным каналом (внутренним ящиком): сбой дополнительного только логируется,
+// иначе повторная отправка продублировала бы уже доставленное в ящик оповещение.
+type AlertNotifiers struct {
+	primary services.AlertNotifier
+	extra   []services.AlertNotifier
+}
+
+func NewAlertNotifiers(primary services.AlertNotifier, extra ...services.AlertNotifier) *AlertNotifiers {
+	return &AlertNotifiers{primary: primary, extra: extra}
+}
+
+func (n *AlertNotifiers) NotifyVacancyAlert(ctx context.Context, alert entities.VacancyAlert) error {
+	if err := n.primary.NotifyVacancyAlert(ctx, alert); err != nil {
+		return err
+	}
+	for _, notifier := range n.extra {
+		if err := notifier.NotifyVacancyAlert(ctx, alert); err != nil {
+			log.Printf("failed to deliver alert for saved search %s: %v", alert.Search.ID, err)
+		}
+	}
+	return nil
+}
+
+// InboxAlertNotifier публикует оповещение во внутренний почтовый ящик пользователя
+type InboxAlertNotifier struct {
+	publisher services.NotificationPublisher
+}
+
+func NewInboxAlertNotifier(publisher services.NotificationPublisher) *InboxAlertNotifier {
+	return &InboxAlertNotifier{publisher: publisher}
+}
+
+func (n *InboxAlertNotifier) NotifyVacancyAlert(ctx context.Context, alert entities.VacancyAlert) error {
+	ids := make([]string, len(alert.Vacancies))
+	for i, vacancy := range alert.Vacancies {
+		ids[i] = vacancy.ID
+	}
+	return n.publisher.Publish(ctx, &entities.Notification{
+		UserID: alert.Search.UserID,
+		Type:   entities.NotificationTypeVacancyAlert,
+		Title:  alertSubject(alert),
+		Body:   strings.Join(alertLines(alert), "\n"),
+		Data: map[string]interface{}{
+			"saved_search_id": alert.Search.ID,
+			"vacancy_ids":     ids,
+		},
+	})
+}
+
+// EmailAlertNotifier отправляет оповещение письмом, если пользователь указал email и не отключил письма
+type EmailAlertNotifier struct {
+	userRepo repositories.UserRepository
+	email    services.EmailSender
+}
+
+func NewEmailAlertNotifier(userRepo repositories.UserRepository, email services.EmailSender) *EmailAlertNotifier {
+	return &EmailAlertNotifier{userRepo: userRepo, email: email}
+}
+
+func (n *EmailAlertNotifier) NotifyVacancyAlert(ctx context.Context, alert entities.VacancyAlert) error {
+	user, err := n.userRepo.FindByID(ctx, alert.Search.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil || user.Email == "" || !user.ContactPreferences.Email {
+		return nil
+	}
+
+	body := fmt.Sprintf("По сохраненному поиску «%s» найдены новые вакансии:\n\n%s\n\nУправлять оповещениями можно в разделе сохраненных поисков.",
+		alert.Search.Name, strings.Join(alertLines(alert), "\n"))
+	return n.email.SendEmail(ctx, user.Email, alertSubject(alert), body)
+}
+
+func alertSubject(alert entities.VacancyAlert) string {
+	if len(alert.Vacancies) == 1 {
+		return fmt.Sprintf("Новая вакансия по поиску «%s»", alert.Search.Name)
+	}
+	return fmt.Sprintf("%d новых вакансий по поиску «%s»", len(alert.Vacancies), alert.Search.Name)
+}
+
+// alertLines описывает каждую вакансию одной строкой: название, компания, город и зарплата
+func alertLines(alert entities.VacancyAlert) []string {
+	lines := make([]string, len(alert.Vacancies))
+	for i, vacancy := range alert.Vacancies {
+		parts := []string{vacancy.Title}
+		if vacancy.Company != nil {
+			parts = append(parts, vacancy.Company.Name)
+		}
+		if vacancy.Location != "" {
+			parts = append(parts, vacancy.Location)
+		}
+		if salary := vacancySalary(vacancy); salary != "" {
+			parts = append(parts, salary)
+		}
+		lines[i] = "• " + strings.Join(parts, ", ")
+	}
+	return lines
+}
+
+func vacancySalary(v *entities.Vacancy) string {
+	switch {
+	case v.SalaryFixed != nil:
+		return fmt.Sprintf("%d", *v.SalaryFixed)
+	case v.SalaryFrom != nil && v.SalaryTo != nil:
+		return fmt.Sprintf("%d–%d", *v.SalaryFrom, *v.SalaryTo)
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/services"
+)
+
+// NewSendersFromEnv создает отправителей SMS и email по переменным окружения.
+//
+// NOTIFY_SMS_DRIVER: console (по умолчанию), http, outbox
+// NOTIFY_EMAIL_DRIVER: console (по умолчанию), smtp, outbox
+func NewSendersFromEnv() (services.SMSSender, services.EmailSender, error) {
+	var outbox *FileOutbox
+	getOutbox := func() (*FileOutbox, error) {
+		if outbox != nil {
+			return outbox, nil
+		}
+		path := os.Getenv("NOTIFY_OUTBOX_PATH")
+		if path == "" {
+			path = "outbox.jsonl"
+		}
+		var err error
+		outbox, err = NewFileOutbox(path)
+		return outbox, err
+	}
+
+	var sms services.SMSSender
+	switch driver := os.Getenv("NOTIFY_SMS_DRIVER"); driver {
+	case "", "console":
+		sms = NewConsoleSender()
+	case "http":
+		sender, err := NewHTTPSMSSender(SMSGatewayConfig{
+			URL:    os.Getenv("SMS_GATEWAY_URL"),
+			APIKey: os.Getenv("SMS_GATEWAY_API_KEY"),
+			Sender: os.Getenv("SMS_GATEWAY_SENDER"),
+		}, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		sms = sender
+	case "outbox":
+		sender, err := getOutbox()
+		if err != nil {
+			return nil, nil, err
+		}
+		sms = sender
+	default:
+		return nil, nil, fmt.Errorf("unknown NOTIFY_SMS_DRIVER %q", driver)
+	}
+
+	var email services.EmailSender
+	switch driver := os.Getenv("NOTIFY_EMAIL_DRIVER"); driver {
+	case "", "console":
+		email = NewConsoleSender()
+	case "smtp":
+		sender, err := NewSMTPEmailSender(SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		email = sender
+	case "outbox":
+		sender, err := getOutbox()
+		if err != nil {
+			return nil, nil, err
+		}
+		email = sender
+	default:
+		return nil, nil, fmt.Errorf("unknown NOTIFY_EMAIL_DRIVER %q", driver)
+	}
+
+	return sms, email, nil
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// ConsoleSender печатает сообщения в stdout вместо отправки. Используется при разработке.
+type ConsoleSender struct{}
+
+func NewConsoleSender() *ConsoleSender {
+	return &ConsoleSender{}
+}
+
+func (s *ConsoleSender) SendSMS(ctx context.Context, phone, message string) error {
+	log.Printf("📱 SMS: Sending message to %s", phone)
+	fmt.Printf("===================================\n")
+	fmt.Printf("SMS\n")
+	fmt.Printf("To: %s\n", phone)
+	fmt.Printf("Text: %s\n", message)
+	fmt.Printf("===================================\n")
+	return nil
+}
+
+func (s *ConsoleSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	log.Printf("📧 EMAIL: Sending message to %s", to)
+	fmt.Printf("===================================\n")
+	fmt.Printf("EMAIL\n")
+	fmt.Printf("To: %s\n", to)
+	fmt.Printf("Subject: %s\n", subject)
+	fmt.Printf("%s\n", body)
+	fmt.Printf("===================================\n")
+	return nil
+}
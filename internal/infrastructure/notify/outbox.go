@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// OutboxMessage сообщение, записанное в файловый outbox
+type OutboxMessage struct {
+	Channel string    `json:"channel"` // "sms" или "email"
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// FileOutbox записывает отправляемые сообщения в файл построчно в формате JSON.
+// Позволяет тестам и локальным сценариям читать отправленные коды.
+type FileOutbox struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileOutbox(path string) (*FileOutbox, error) {
+	if path == "" {
+		return nil, errors.New("outbox path is required")
+	}
+	return &FileOutbox{path: path}, nil
+}
+
+func (o *FileOutbox) SendSMS(ctx context.Context, phone, message string) error {
+	return o.append(OutboxMessage{Channel: "sms", To: phone, Body: message, SentAt: time.Now()})
+}
+
+func (o *FileOutbox) SendEmail(ctx context.Context, to, subject, body string) error {
+	return o.append(OutboxMessage{Channel: "email", To: to, Subject: subject, Body: body, SentAt: time.Now()})
+}
+
+// Messages возвращает все сообщения из outbox в порядке отправки
+func (o *FileOutbox) Messages() ([]OutboxMessage, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	f, err := os.Open(o.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []OutboxMessage{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	messages := []OutboxMessage{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var msg OutboxMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, scanner.Err()
+}
+
+// LastMessageTo возвращает последнее сообщение для получателя или nil, если сообщений не было
+func (o *FileOutbox) LastMessageTo(to string) (*OutboxMessage, error) {
+	messages, err := o.Messages()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].To == to {
+			return &messages[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *FileOutbox) append(msg OutboxMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	f, err := os.OpenFile(o.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SMSGatewayConfig параметры HTTP-шлюза SMS
+type SMSGatewayConfig struct {
+	URL    string
+	APIKey string
+	Sender string
+}
+
+// HTTPSMSSender отправляет SMS через HTTP-шлюз.
+// Шлюз принимает POST с JSON {"to", "from", "text"} и bearer-ключом в заголовке Authorization.
+type HTTPSMSSender struct {
+	config SMSGatewayConfig
+	client *http.Client
+}
+
+func NewHTTPSMSSender(config SMSGatewayConfig, client *http.Client) (*HTTPSMSSender, error) {
+	if config.URL == "" {
+		return nil, errors.New("sms gateway url is required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &HTTPSMSSender{config: config, client: client}, nil
+}
+
+func (s *HTTPSMSSender) SendSMS(ctx context.Context, phone, message string) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":   phone,
+		"from": s.config.Sender,
+		"text": message,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms gateway request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sms gateway returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPConfig параметры подключения к SMTP-серверу
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPEmailSender отправляет письма через SMTP-сервер
+type SMTPEmailSender struct {
+	config SMTPConfig
+}
+
+func NewSMTPEmailSender(config SMTPConfig) (*SMTPEmailSender, error) {
+	if config.Host == "" || config.From == "" {
+		return nil, errors.New("smtp host and from address are required")
+	}
+	if config.Port == "" {
+		config.Port = "587"
+	}
+	return &SMTPEmailSender{config: config}, nil
+}
+
+func (s *SMTPEmailSender) SendEmail(ctx context.Context, to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return errors.New("invalid recipient address")
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	msg := buildEmailMessage(s.config.From, to, subject, body)
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+
+	// net/smtp не поддерживает context, поэтому отправка выполняется в горутине
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, s.config.From, []string{to}, msg)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func buildEmailMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
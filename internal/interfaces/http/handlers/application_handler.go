@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type ApplicationHandler struct {
+	Service *usecases.ApplicationService
+}
+
+func NewApplicationHandler(service *usecases.ApplicationService) *ApplicationHandler {
+	return &ApplicationHandler{Service: service}
+}
+
+// Apply создает отклик текущего студента на вакансию
+// POST /api/vacancies/:id/applications
+func (h *ApplicationHandler) Apply(c *gin.Context) {
+	vacancyID := c.Param("id")
+
+	var req struct {
+		CoverLetter string `json:"cover_letter"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	application, err := h.Service.Apply(c.Request.Context(), user.ID, vacancyID, req.CoverLetter)
+	if err != nil {
+		if errors.Is(err, usecases.ErrAlreadyApplied) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err.Error() == "vacancy not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "application submitted successfully",
+		"data":    application,
+	})
+}
+
+// ChangeStatus переводит отклик на другой этап
+// PATCH /api/applications/:id/status
+func (h *ApplicationHandler) ChangeStatus(c *gin.Context) {
+	id := c.Param("id")
+
+	var req struct {
+		Status string `json:"status" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	application, err := h.Service.ChangeStatus(c.Request.Context(), user.ID, id, req.Status)
+	if err != nil {
+		var transitionErr *usecases.InvalidStatusTransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error": err.Error(),
+				"from":  transitionErr.From,
+				"to":    transitionErr.To,
+			})
+		case errors.Is(err, repositories.ErrApplicationStatusConflict):
+			c.JSON(http.StatusConflict, gin.H{
+				"error": err.Error(),
+			})
+		case errors.Is(err, usecases.ErrNotVacancyOwner) || errors.Is(err, usecases.ErrCompanyForbidden):
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+		case err.Error() == "application not found":
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "application status updated successfully",
+		"data":    application,
+	})
+}
+
+// GetVacancyApplications получает отклики на вакансию текущего работодателя
+// GET /api/vacancies/:id/applications
+func (h *ApplicationHandler) GetVacancyApplications(c *gin.Context) {
+	vacancyID := c.Param("id")
+
+	user := middlewares.CurrentUser(c)
+	applications, err := h.Service.GetVacancyApplications(c.Request.Context(), user.ID, vacancyID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrNotVacancyOwner) || errors.Is(err, usecases.ErrCompanyForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		if err.Error() == "vacancy not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  applications,
+		"count": len(applications),
+	})
+}
+
+// GetMyApplications получает отклики текущего студента
+// GET /api/students/me/applications
+func (h *ApplicationHandler) GetMyApplications(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	applications, err := h.Service.GetStudentApplications(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  applications,
+		"count": len(applications),
+	})
+}
@@ -1,12 +1,29 @@
 package handlers
 
 import (
+    "errors"
+    "math"
     "net/http"
-    "time"
+    "strconv"
     "github.com/gin-gonic/gin"
     "github.com/albkvv/student-job-finder-back/internal/application/usecases"
 )
 
+// respondRateLimited отвечает 429 с заголовком Retry-After, если err — ошибка ограничения частоты
+func respondRateLimited(c *gin.Context, err error) bool {
+    var rateErr *usecases.RateLimitError
+    if !errors.As(err, &rateErr) {
+        return false
+    }
+    seconds := int(math.Ceil(rateErr.RetryAfter.Seconds()))
+    if seconds < 1 {
+        seconds = 1
+    }
+    c.Header("Retry-After", strconv.Itoa(seconds))
+    c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error(), "retry_after": seconds})
+    return true
+}
+
 type AuthHandler struct {
     Service *usecases.AuthService
 }
@@ -27,23 +44,13 @@ func (h *AuthHandler) RegisterPassword(c *gin.Context) {
         return
     }
     
-    user, token, err := h.Service.RegisterPassword(c.Request.Context(), req.Email, req.Phone, req.Password, req.Role)
+    user, tokens, err := h.Service.RegisterPassword(c.Request.Context(), req.Email, req.Phone, req.Password, req.Role)
     if err != nil {
         c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
     
-    c.JSON(http.StatusCreated, gin.H{
-        "token": token,
-        "user": gin.H{
-            "id":          user.ID,
-            "email":       user.Email,
-            "phone":       user.Phone,
-            "role":        user.Role,
-            "is_verified": user.IsVerified,
-        },
-        "expiresAt": time.Now().Add(24 * time.Hour).Unix(),
-    })
+    c.JSON(http.StatusCreated, authResponse(user, tokens))
 }
 
 func (h *AuthHandler) LoginPassword(c *gin.Context) {
@@ -56,23 +63,13 @@ func (h *AuthHandler) LoginPassword(c *gin.Context) {
         return
     }
     
-    user, token, err := h.Service.LoginPassword(c.Request.Context(), req.Identifier, req.Password)
+    user, tokens, err := h.Service.LoginPassword(c.Request.Context(), req.Identifier, req.Password)
     if err != nil {
         c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
         return
     }
     
-    c.JSON(http.StatusOK, gin.H{
-        "token": token,
-        "user": gin.H{
-            "id":          user.ID,
-            "email":       user.Email,
-            "phone":       user.Phone,
-            "role":        user.Role,
-            "is_verified": user.IsVerified,
-        },
-        "expiresAt": time.Now().Add(24 * time.Hour).Unix(),
-    })
+    c.JSON(http.StatusOK, authResponse(user, tokens))
 }
 
 func (h *AuthHandler) RequestCode(c *gin.Context) {
@@ -84,8 +81,11 @@ func (h *AuthHandler) RequestCode(c *gin.Context) {
         c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
-    err := h.Service.RequestPhoneCode(c.Request.Context(), req.Phone, req.Role)
+    err := h.Service.RequestPhoneCode(c.Request.Context(), req.Phone, req.Role, c.ClientIP())
     if err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
         c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
     }
@@ -95,13 +95,17 @@ func (h *AuthHandler) RequestCode(c *gin.Context) {
 func (h *AuthHandler) RequestEmailCode(c *gin.Context) {
     var req struct {
         Email string `json:"email" binding:"required"`
+        Role  string `json:"role" binding:"omitempty,oneof=student employer"`
     }
     if err := c.ShouldBindJSON(&req); err != nil {
         c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
-    err := h.Service.RequestEmailCode(c.Request.Context(), req.Email)
+    err := h.Service.RequestEmailCode(c.Request.Context(), req.Email, req.Role, c.ClientIP())
     if err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
         c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
     }
@@ -117,22 +121,15 @@ func (h *AuthHandler) VerifyCode(c *gin.Context) {
         c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
-    user, token, err := h.Service.VerifyPhoneCode(c.Request.Context(), req.Phone, req.Code)
+    user, tokens, err := h.Service.VerifyPhoneCode(c.Request.Context(), req.Phone, req.Code)
     if err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
         c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
         return
     }
-    c.JSON(http.StatusOK, gin.H{
-        "token": token,
-        "user": gin.H{
-            "id":          user.ID,
-            "phone":       user.Phone,
-            "role":        user.Role,
-            "name":        user.Name,
-            "is_verified": user.IsVerified,
-        },
-        "expiresAt": time.Now().Add(24 * time.Hour).Unix(),
-    })
+    c.JSON(http.StatusOK, authResponse(user, tokens))
 }
 
 func (h *AuthHandler) VerifyEmailCode(c *gin.Context) {
@@ -144,21 +141,94 @@ func (h *AuthHandler) VerifyEmailCode(c *gin.Context) {
         c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
-    user, token, err := h.Service.VerifyEmailCode(c.Request.Context(), req.Email, req.Code)
+    user, tokens, err := h.Service.VerifyEmailCode(c.Request.Context(), req.Email, req.Code)
     if err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
         c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
         return
     }
-    c.JSON(http.StatusOK, gin.H{
-        "token": token,
-        "user": gin.H{
-            "id":          user.ID,
-            "email":       user.Email,
-            "role":        user.Role,
-            "name":        user.Name,
-            "is_verified": user.IsVerified,
-        },
-        "expiresAt": time.Now().Add(24 * time.Hour).Unix(),
-    })
+    c.JSON(http.StatusOK, authResponse(user, tokens))
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+    var req struct {
+        RefreshToken string `json:"refresh_token" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    user, tokens, err := h.Service.RefreshTokens(c.Request.Context(), req.RefreshToken)
+    if err != nil {
+        if errors.Is(err, usecases.ErrInvalidRefreshToken) || errors.Is(err, usecases.ErrRefreshTokenReused) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, authResponse(user, tokens))
 }
 
+func (h *AuthHandler) Logout(c *gin.Context) {
+    var req struct {
+        RefreshToken string `json:"refresh_token" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    if err := h.Service.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+        if errors.Is(err, usecases.ErrInvalidRefreshToken) {
+            c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+    var req struct {
+        Identifier string `json:"identifier" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    if err := h.Service.RequestPasswordReset(c.Request.Context(), req.Identifier, c.ClientIP()); err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
+        if err.Error() == "invalid identifier format" {
+            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            return
+        }
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "if the account exists, a reset code has been sent"})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+    var req struct {
+        Identifier  string `json:"identifier" binding:"required"`
+        Code        string `json:"code" binding:"required"`
+        NewPassword string `json:"new_password" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    if err := h.Service.ResetPassword(c.Request.Context(), req.Identifier, req.Code, req.NewPassword); err != nil {
+        if respondRateLimited(c, err) {
+            return
+        }
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+    c.JSON(http.StatusOK, gin.H{"message": "password has been reset"})
+}
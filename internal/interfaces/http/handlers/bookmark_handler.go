@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type BookmarkHandler struct {
+	Service *usecases.BookmarkService
+}
+
+func NewBookmarkHandler(service *usecases.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{Service: service}
+}
+
+// SaveVacancy добавляет вакансию в закладки текущего студента
+// POST /api/students/me/saved-vacancies
+func (h *BookmarkHandler) SaveVacancy(c *gin.Context) {
+	var req struct {
+		VacancyID string `json:"vacancy_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	bookmark, err := h.Service.SaveVacancy(c.Request.Context(), user.ID, req.VacancyID)
+	if err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "vacancy saved successfully",
+		"data":    bookmark,
+	})
+}
+
+// RemoveVacancy удаляет вакансию из закладок текущего студента
+// DELETE /api/students/me/saved-vacancies/:vacancyId
+func (h *BookmarkHandler) RemoveVacancy(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.RemoveVacancy(c.Request.Context(), user.ID, c.Param("vacancyId")); err != nil {
+		respondBookmarkError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "vacancy removed from saved successfully",
+	})
+}
+
+// GetSavedVacancies возвращает сохраненные вакансии текущего студента
+// GET /api/students/me/saved-vacancies
+func (h *BookmarkHandler) GetSavedVacancies(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	saved, err := h.Service.GetSavedVacancies(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  saved,
+		"count": len(saved),
+	})
+}
+
+func respondBookmarkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrBookmarkExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, repositories.ErrBookmarkNotFound) || err.Error() == "vacancy not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type CompanyHandler struct {
+	Service *usecases.CompanyService
+}
+
+func NewCompanyHandler(service *usecases.CompanyService) *CompanyHandler {
+	return &CompanyHandler{Service: service}
+}
+
+// CreateCompany создает компанию текущего работодателя
+// POST /api/companies
+func (h *CompanyHandler) CreateCompany(c *gin.Context) {
+	var req entities.Company
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.CreateCompany(c.Request.Context(), user.ID, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "company created successfully",
+		"data":    req,
+	})
+}
+
+// GetCompany получает публичный профиль компании и страницу ее активных вакансий.
+// Состав команды анонимным посетителям не раскрывается.
+// GET /api/companies/:id?limit=20&cursor=...
+func (h *CompanyHandler) GetCompany(c *gin.Context) {
+	id := c.Param("id")
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+
+	company, err := h.Service.GetCompany(c.Request.Context(), id)
+	if err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	vacancies, err := h.Service.GetCompanyVacancies(c.Request.Context(), id, limit, c.Query("cursor"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repositories.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        company.Profile(),
+		"vacancies":   vacancies.Items,
+		"total":       vacancies.Total,
+		"next_cursor": vacancies.NextCursor,
+	})
+}
+
+// GetMyCompanies получает компании, в команде которых состоит текущий работодатель
+// GET /api/employers/me/companies
+func (h *CompanyHandler) GetMyCompanies(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	companies, err := h.Service.GetUserCompanies(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  companies,
+		"count": len(companies),
+	})
+}
+
+// UpdateCompany обновляет профиль компании
+// PUT /api/companies/:id
+func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
+	var req entities.Company
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.ID = c.Param("id")
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.UpdateCompany(c.Request.Context(), user.ID, &req); err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "company updated successfully",
+	})
+}
+
+// DeleteCompany удаляет компанию без вакансий
+// DELETE /api/companies/:id
+func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.DeleteCompany(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "company deleted successfully",
+	})
+}
+
+// InviteMember приглашает пользователя в команду компании по email или телефону
+// POST /api/companies/:id/invitations
+func (h *CompanyHandler) InviteMember(c *gin.Context) {
+	var req struct {
+		Identifier string `json:"identifier" binding:"required"`
+		Role       string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	invitation, err := h.Service.InviteMember(c.Request.Context(), user.ID, c.Param("id"), req.Identifier, req.Role, c.ClientIP())
+	if err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "invitation sent successfully",
+		"data":    invitation,
+	})
+}
+
+// RevokeInvitation отменяет приглашение
+// DELETE /api/companies/:id/invitations?identifier=...
+func (h *CompanyHandler) RevokeInvitation(c *gin.Context) {
+	identifier := c.Query("identifier")
+	if identifier == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "identifier is required",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.RevokeInvitation(c.Request.Context(), user.ID, c.Param("id"), identifier); err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "invitation revoked successfully",
+	})
+}
+
+// AcceptInvitation принимает приглашение в команду по коду
+// POST /api/companies/:id/invitations/accept
+func (h *CompanyHandler) AcceptInvitation(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	company, err := h.Service.AcceptInvitation(c.Request.Context(), middlewares.CurrentUser(c), c.Param("id"), req.Code)
+	if err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "invitation accepted successfully",
+		"data":    company,
+	})
+}
+
+// ChangeMemberRole меняет роль участника команды
+// PATCH /api/companies/:id/members/:userId
+func (h *CompanyHandler) ChangeMemberRole(c *gin.Context) {
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	company, err := h.Service.ChangeMemberRole(c.Request.Context(), user.ID, c.Param("id"), c.Param("userId"), req.Role)
+	if err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "member role updated successfully",
+		"data":    company,
+	})
+}
+
+// RemoveMember исключает участника из команды или выводит из нее текущего пользователя
+// DELETE /api/companies/:id/members/:userId
+func (h *CompanyHandler) RemoveMember(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	company, err := h.Service.RemoveMember(c.Request.Context(), user.ID, c.Param("id"), c.Param("userId"))
+	if err != nil {
+		respondCompanyError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "member removed successfully",
+		"data":    company,
+	})
+}
+
+func respondCompanyError(c *gin.Context, err error) {
+	if respondRateLimited(c, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, usecases.ErrCompanyForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, usecases.ErrCompanyHasVacancies),
+		errors.Is(err, repositories.ErrCompanyTeamConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	case err.Error() == "company not found" || err.Error() == "user is not a company member":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type ConversationHandler struct {
+	Service *usecases.ConversationService
+}
+
+func NewConversationHandler(service *usecases.ConversationService) *ConversationHandler {
+	return &ConversationHandler{Service: service}
+}
+
+// StartConversation открывает переписку по вакансии или возвращает существующую.
+// Работодатель указывает студента в student_id, студент — только вакансию.
+// POST /api/conversations
+func (h *ConversationHandler) StartConversation(c *gin.Context) {
+	var req struct {
+		VacancyID string `json:"vacancy_id" binding:"required"`
+		StudentID string `json:"student_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	conversation, err := h.Service.StartConversation(c.Request.Context(), user, req.VacancyID, req.StudentID)
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "conversation is ready",
+		"data":    conversation,
+	})
+}
+
+// GetConversations возвращает переписки текущего пользователя, последние активные первыми
+// GET /api/conversations
+func (h *ConversationHandler) GetConversations(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	conversations, total, err := h.Service.GetConversations(c.Request.Context(), user, limit, offset)
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  conversations,
+		"count": len(conversations),
+		"total": total,
+	})
+}
+
+// GetUnreadCount возвращает число непрочитанных сообщений во всех переписках текущего пользователя
+// GET /api/conversations/unread-count
+func (h *ConversationHandler) GetUnreadCount(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	unread, err := h.Service.UnreadCount(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread": unread,
+	})
+}
+
+// GetConversation возвращает переписку
+// GET /api/conversations/:id
+func (h *ConversationHandler) GetConversation(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	conversation, err := h.Service.GetConversation(c.Request.Context(), user, c.Param("id"))
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": conversation,
+	})
+}
+
+// GetMessages возвращает сообщения переписки, новые первыми
+// GET /api/conversations/:id/messages
+func (h *ConversationHandler) GetMessages(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	messages, err := h.Service.GetMessages(c.Request.Context(), user, c.Param("id"), c.Query("before"), limit)
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  messages,
+		"count": len(messages),
+	})
+}
+
+// SendMessage отправляет сообщение в переписку
+// POST /api/conversations/:id/messages
+func (h *ConversationHandler) SendMessage(c *gin.Context) {
+	var req struct {
+		Body        string                       `json:"body"`
+		Attachments []entities.MessageAttachment `json:"attachments"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	message := &entities.Message{Body: req.Body, Attachments: req.Attachments}
+	if err := h.Service.SendMessage(c.Request.Context(), user, c.Param("id"), message); err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "message sent successfully",
+		"data":    message,
+	})
+}
+
+// EditMessage изменяет текст своего сообщения
+// PATCH /api/conversations/:id/messages/:messageId
+func (h *ConversationHandler) EditMessage(c *gin.Context) {
+	var req struct {
+		Body string `json:"body"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	message, err := h.Service.EditMessage(c.Request.Context(), user, c.Param("id"), c.Param("messageId"), req.Body)
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "message updated successfully",
+		"data":    message,
+	})
+}
+
+// DeleteMessage удаляет свое сообщение
+// DELETE /api/conversations/:id/messages/:messageId
+func (h *ConversationHandler) DeleteMessage(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.DeleteMessage(c.Request.Context(), user, c.Param("id"), c.Param("messageId")); err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "message deleted successfully",
+	})
+}
+
+// MarkRead отмечает прочитанными сообщения собеседника в переписке
+// POST /api/conversations/:id/read
+func (h *ConversationHandler) MarkRead(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	read, err := h.Service.MarkRead(c.Request.Context(), user, c.Param("id"))
+	if err != nil {
+		respondConversationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "messages marked as read",
+		"updated": read,
+	})
+}
+
+func respondConversationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrConversationNotAllowed),
+		errors.Is(err, usecases.ErrNotMessageSender),
+		errors.Is(err, usecases.ErrNotVacancyOwner),
+		errors.Is(err, usecases.ErrCompanyForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, repositories.ErrConversationNotFound),
+		errors.Is(err, repositories.ErrMessageNotFound),
+		err.Error() == "vacancy not found":
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
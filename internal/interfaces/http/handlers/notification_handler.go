@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+// sseRetry через сколько миллисекунд браузер переподключается к потоку после обрыва
+const sseRetry = 5000
+
+type NotificationHandler struct {
+	Service   *usecases.NotificationService
+	Heartbeat time.Duration // интервал ping-комментариев в потоке событий
+}
+
+func NewNotificationHandler(service *usecases.NotificationService, heartbeat time.Duration) *NotificationHandler {
+	return &NotificationHandler{
+		Service:   service,
+		Heartbeat: heartbeat,
+	}
+}
+
+// GetNotifications возвращает уведомления текущего пользователя: сначала непрочитанные, затем остальные
+// GET /api/notifications
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	notifications, total, unread, err := h.Service.List(c.Request.Context(), user.ID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   notifications,
+		"count":  len(notifications),
+		"total":  total,
+		"unread": unread,
+	})
+}
+
+// GetUnreadCount возвращает число непрочитанных уведомлений текущего пользователя
+// GET /api/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	unread, err := h.Service.UnreadCount(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread": unread,
+	})
+}
+
+// MarkRead отмечает уведомление прочитанным
+// PATCH /api/notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.MarkRead(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notification marked as read",
+	})
+}
+
+// MarkAllRead отмечает прочитанными все уведомления текущего пользователя
+// POST /api/notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	updated, err := h.Service.MarkAllRead(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "all notifications marked as read",
+		"updated": updated,
+	})
+}
+
+// DeleteNotification удаляет уведомление
+// DELETE /api/notifications/:id
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.Delete(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		respondNotificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notification deleted successfully",
+	})
+}
+
+// Stream отправляет уведомления текущего пользователя в реальном времени (Server-Sent Events).
+// При переподключении с Last-Event-ID сначала приходят уведомления, пропущенные после этого события.
+// GET /api/notifications/stream
+func (h *NotificationHandler) Stream(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	user := middlewares.CurrentUser(c)
+	replay, events, err := h.Service.Subscribe(c.Request.Context(), user.ID, lastEventID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", sseRetry)
+	replayed := make(map[string]bool, len(replay))
+	for _, event := range replay {
+		replayed[event.ID] = true
+		if err := writeSSE(c.Writer, event); err != nil {
+			return
+		}
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-events:
+			// Канал закрывается, если клиент не успевал читать: он переподключится и догонит по Last-Event-ID
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			if err := writeSSE(c.Writer, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeSSE записывает событие в формате Server-Sent Events
+func writeSSE(w io.Writer, event entities.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if event.ID != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func respondNotificationError(c *gin.Context, err error) {
+	if errors.Is(err, repositories.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileHandler struct {
+	Service *usecases.AuthService
+}
+
+func NewProfileHandler(service *usecases.AuthService) *ProfileHandler {
+	return &ProfileHandler{Service: service}
+}
+
+// GetMe возвращает профиль текущего пользователя
+// GET /api/me
+func (h *ProfileHandler) GetMe(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": newUserResponse(middlewares.CurrentUser(c))})
+}
+
+// UpdateMe изменяет имя, аватар и предпочтения связи
+// PATCH /api/me
+func (h *ProfileHandler) UpdateMe(c *gin.Context) {
+	var req struct {
+		Name               *string                      `json:"name"`
+		AvatarURL          *string                      `json:"avatar_url"`
+		ContactPreferences *entities.ContactPreferences `json:"contact_preferences"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user, err := h.Service.UpdateProfile(c.Request.Context(), middlewares.CurrentUser(c), usecases.ProfileUpdate{
+		Name:               req.Name,
+		AvatarURL:          req.AvatarURL,
+		ContactPreferences: req.ContactPreferences,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": newUserResponse(user)})
+}
+
+// ChangePassword меняет пароль и возвращает новую пару токенов; остальные сессии завершаются
+// POST /api/me/change-password
+func (h *ProfileHandler) ChangePassword(c *gin.Context) {
+	var req struct {
+		CurrentPassword string `json:"current_password"`
+		Code            string `json:"code"`
+		NewPassword     string `json:"new_password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	tokens, err := h.Service.ChangePassword(c.Request.Context(), user, req.CurrentPassword, req.Code, req.NewPassword)
+	if err != nil {
+		if respondRateLimited(c, err) {
+			return
+		}
+		if errors.Is(err, usecases.ErrInvalidCurrentPassword) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponse(user, tokens))
+}
+
+// RequestPasswordSetCode отправляет код для установки первого пароля на email или телефон пользователя
+// POST /api/me/change-password/code
+func (h *ProfileHandler) RequestPasswordSetCode(c *gin.Context) {
+	if err := h.Service.RequestPasswordSetCode(c.Request.Context(), middlewares.CurrentUser(c), c.ClientIP()); err != nil {
+		if errors.Is(err, usecases.ErrPasswordAlreadySet) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		respondContactChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "code sent"})
+}
+
+// RequestEmailChange отправляет код на новый email
+// POST /api/me/change-email
+func (h *ProfileHandler) RequestEmailChange(c *gin.Context) {
+	h.requestChange(c, "email", h.Service.RequestEmailChange)
+}
+
+// ConfirmEmailChange подтверждает новый email кодом
+// POST /api/me/change-email/confirm
+func (h *ProfileHandler) ConfirmEmailChange(c *gin.Context) {
+	h.confirmChange(c, "email", h.Service.ConfirmEmailChange)
+}
+
+// RequestPhoneChange отправляет код на новый телефон
+// POST /api/me/change-phone
+func (h *ProfileHandler) RequestPhoneChange(c *gin.Context) {
+	h.requestChange(c, "phone", h.Service.RequestPhoneChange)
+}
+
+// ConfirmPhoneChange подтверждает новый телефон кодом
+// POST /api/me/change-phone/confirm
+func (h *ProfileHandler) ConfirmPhoneChange(c *gin.Context) {
+	h.confirmChange(c, "phone", h.Service.ConfirmPhoneChange)
+}
+
+// requestChange читает новый контакт из поля field и запрашивает для него код
+func (h *ProfileHandler) requestChange(c *gin.Context, field string,
+	request func(ctx context.Context, user *entities.User, identifier, clientIP string) error) {
+	var req map[string]string
+	if err := c.ShouldBindJSON(&req); err != nil || req[field] == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": field + " is required"})
+		return
+	}
+
+	if err := request(c.Request.Context(), middlewares.CurrentUser(c), req[field], c.ClientIP()); err != nil {
+		respondContactChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "code sent to new " + field})
+}
+
+// confirmChange проверяет код для нового контакта из поля field и возвращает обновленный профиль
+func (h *ProfileHandler) confirmChange(c *gin.Context, field string,
+	confirm func(ctx context.Context, user *entities.User, identifier, code string) (*entities.User, error)) {
+	var req map[string]string
+	if err := c.ShouldBindJSON(&req); err != nil || req[field] == "" || req["code"] == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": field + " and code are required"})
+		return
+	}
+
+	user, err := confirm(c.Request.Context(), middlewares.CurrentUser(c), req[field], req["code"])
+	if err != nil {
+		respondContactChangeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": newUserResponse(user)})
+}
+
+func respondContactChangeError(c *gin.Context, err error) {
+	if respondRateLimited(c, err) {
+		return
+	}
+	if errors.Is(err, repositories.ErrUserExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
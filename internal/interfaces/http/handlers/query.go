@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+// queryInt читает целочисленный query-параметр; отсутствующий параметр возвращает 0
+func queryInt(c *gin.Context, key string) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(raw)
+}
+
+// queryList читает query-параметр со значениями через запятую
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, part := range strings.Split(c.Query(key), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// parseVacancyFilter собирает фильтр вакансий из query-параметров
+func parseVacancyFilter(c *gin.Context) (repositories.VacancyFilter, error) {
+	filter := repositories.VacancyFilter{
+		CompanyID: c.Query("company_id"),
+		Status:    c.Query("status"),
+		Type:      c.Query("type"),
+		Format:    c.Query("format"),
+		Location:  strings.TrimSpace(c.Query("location")),
+		Skills:    entities.NormalizeSkills(queryList(c, "skills")),
+	}
+
+	if raw := c.Query("min_salary"); raw != "" {
+		minSalary, err := strconv.Atoi(raw)
+		if err != nil {
+			return filter, errors.New("invalid min_salary")
+		}
+		filter.MinSalary = &minSalary
+	}
+
+	switch c.DefaultQuery("skills_match", "any") {
+	case "any":
+	case "all":
+		filter.SkillsMatchAll = true
+	default:
+		return filter, errors.New("invalid skills_match, must be 'any' or 'all'")
+	}
+
+	if raw := c.Query("not_expired"); raw != "" {
+		notExpired, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, errors.New("invalid not_expired")
+		}
+		filter.NotExpired = notExpired
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	Service *usecases.RecommendationService
+}
+
+func NewRecommendationHandler(service *usecases.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{Service: service}
+}
+
+// GetRecommendations возвращает вакансии, подобранные по профилю текущего студента, с оценкой и ее объяснением
+// GET /api/recommendations
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	items, total, err := h.Service.RecommendVacancies(c.Request.Context(), user.ID, limit, offset)
+	if err != nil {
+		if errors.Is(err, repositories.ErrStudentProfileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "create a student profile to get recommendations",
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  items,
+		"count": len(items),
+		"total": total,
+	})
+}
+
+// GetVacancyCandidates возвращает студентов, подходящих под вакансию, с оценкой и ее объяснением
+// GET /api/vacancies/:id/candidates
+func (h *RecommendationHandler) GetVacancyCandidates(c *gin.Context) {
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	items, total, err := h.Service.RecommendCandidates(c.Request.Context(), user.ID, c.Param("id"), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrNotVacancyOwner), errors.Is(err, usecases.ErrCompanyForbidden):
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+		case err.Error() == "vacancy not found":
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  items,
+		"count": len(items),
+		"total": total,
+	})
+}
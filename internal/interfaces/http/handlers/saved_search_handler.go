@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	Service *usecases.SavedSearchService
+}
+
+func NewSavedSearchHandler(service *usecases.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{Service: service}
+}
+
+// CreateSearch сохраняет фильтр вакансий с оповещениями
+// POST /api/students/me/saved-searches
+func (h *SavedSearchHandler) CreateSearch(c *gin.Context) {
+	var req entities.SavedSearch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.CreateSearch(c.Request.Context(), user.ID, &req); err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "saved search created successfully",
+		"data":    req,
+	})
+}
+
+// GetSearches возвращает сохраненные поиски текущего студента
+// GET /api/students/me/saved-searches
+func (h *SavedSearchHandler) GetSearches(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	searches, err := h.Service.GetSearches(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  searches,
+		"count": len(searches),
+	})
+}
+
+// GetSearch возвращает сохраненный поиск
+// GET /api/students/me/saved-searches/:id
+func (h *SavedSearchHandler) GetSearch(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	search, err := h.Service.GetSearch(c.Request.Context(), user.ID, c.Param("id"))
+	if err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": search,
+	})
+}
+
+// UpdateSearch заменяет фильтр и частоту оповещений
+// PUT /api/students/me/saved-searches/:id
+func (h *SavedSearchHandler) UpdateSearch(c *gin.Context) {
+	var req entities.SavedSearch
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.ID = c.Param("id")
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.UpdateSearch(c.Request.Context(), user.ID, &req); err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "saved search updated successfully",
+		"data":    req,
+	})
+}
+
+// DeleteSearch удаляет сохраненный поиск
+// DELETE /api/students/me/saved-searches/:id
+func (h *SavedSearchHandler) DeleteSearch(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.DeleteSearch(c.Request.Context(), user.ID, c.Param("id")); err != nil {
+		respondSavedSearchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "saved search deleted successfully",
+	})
+}
+
+func respondSavedSearchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrSavedSearchNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, usecases.ErrTooManySavedSearches):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
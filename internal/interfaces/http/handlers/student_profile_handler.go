@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type StudentProfileHandler struct {
+	Service *usecases.StudentProfileService
+}
+
+func NewStudentProfileHandler(service *usecases.StudentProfileService) *StudentProfileHandler {
+	return &StudentProfileHandler{Service: service}
+}
+
+// GetMyProfile возвращает профиль текущего студента
+// GET /api/students/me/profile
+func (h *StudentProfileHandler) GetMyProfile(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	profile, err := h.Service.GetProfile(c.Request.Context(), user.ID)
+	h.respondProfile(c, profile, err)
+}
+
+// GetStudentProfile возвращает профиль студента для работодателя: откликнувшегося на его вакансию
+// или открытого для подбора
+// GET /api/students/:id/profile
+func (h *StudentProfileHandler) GetStudentProfile(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	profile, err := h.Service.GetProfileForEmployer(c.Request.Context(), user.ID, c.Param("id"))
+	h.respondProfile(c, profile, err)
+}
+
+// CreateMyProfile создает профиль текущего студента
+// POST /api/students/me/profile
+func (h *StudentProfileHandler) CreateMyProfile(c *gin.Context) {
+	var req entities.StudentProfile
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.CreateProfile(c.Request.Context(), user.ID, &req); err != nil {
+		respondStudentProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "student profile created successfully",
+		"data":    req,
+	})
+}
+
+// UpdateMyProfile полностью заменяет профиль текущего студента
+// PUT /api/students/me/profile
+func (h *StudentProfileHandler) UpdateMyProfile(c *gin.Context) {
+	var req entities.StudentProfile
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.UpdateProfile(c.Request.Context(), user.ID, &req); err != nil {
+		respondStudentProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "student profile updated successfully",
+		"data":    req,
+	})
+}
+
+// DeleteMyProfile удаляет профиль текущего студента
+// DELETE /api/students/me/profile
+func (h *StudentProfileHandler) DeleteMyProfile(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.DeleteProfile(c.Request.Context(), user.ID); err != nil {
+		respondStudentProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "student profile deleted successfully",
+	})
+}
+
+func (h *StudentProfileHandler) respondProfile(c *gin.Context, profile *entities.StudentProfile, err error) {
+	if err != nil {
+		if errors.Is(err, repositories.ErrStudentProfileNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": profile,
+	})
+}
+
+func respondStudentProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrStudentProfileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, repositories.ErrStudentProfileExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	}
+}
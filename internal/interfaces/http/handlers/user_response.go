@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+// UserResponse представление пользователя во всех ответах API
+type UserResponse struct {
+	ID                 string                      `json:"id"`
+	Email              string                      `json:"email"`
+	Phone              string                      `json:"phone"`
+	Name               string                      `json:"name"`
+	Role               string                      `json:"role"`
+	IsVerified         bool                        `json:"is_verified"`
+	HasPassword        bool                        `json:"has_password"`
+	AvatarURL          string                      `json:"avatar_url"`
+	ContactPreferences entities.ContactPreferences `json:"contact_preferences"`
+	CreatedAt          time.Time                   `json:"created_at"`
+}
+
+func newUserResponse(user *entities.User) UserResponse {
+	return UserResponse{
+		ID:                 user.ID,
+		Email:              user.Email,
+		Phone:              user.Phone,
+		Name:               user.Name,
+		Role:               user.Role,
+		IsVerified:         user.IsVerified,
+		HasPassword:        user.PasswordHash != "",
+		AvatarURL:          user.AvatarURL,
+		ContactPreferences: user.ContactPreferences,
+		CreatedAt:          user.CreatedAt,
+	}
+}
+
+// authResponse ответ с выпущенной парой токенов и пользователем
+func authResponse(user *entities.User, tokens *entities.TokenPair) gin.H {
+	return gin.H{
+		"token":            tokens.AccessToken,
+		"refresh_token":    tokens.RefreshToken,
+		"user":             newUserResponse(user),
+		"expiresAt":        tokens.AccessExpiresAt.Unix(),
+		"refreshExpiresAt": tokens.RefreshExpiresAt.Unix(),
+	}
+}
@@ -1,19 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
 	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
 type VacancyHandler struct {
-	Service *usecases.VacancyService
+	Service   *usecases.VacancyService
+	Bookmarks *usecases.BookmarkService
 }
 
-func NewVacancyHandler(service *usecases.VacancyService) *VacancyHandler {
-	return &VacancyHandler{Service: service}
+func NewVacancyHandler(service *usecases.VacancyService, bookmarks *usecases.BookmarkService) *VacancyHandler {
+	return &VacancyHandler{Service: service, Bookmarks: bookmarks}
 }
 
 // CreateVacancy создает новую вакансию
@@ -29,7 +33,14 @@ func (h *VacancyHandler) CreateVacancy(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.CreateVacancy(c.Request.Context(), &req); err != nil {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.CreateVacancy(c.Request.Context(), user.ID, &req); err != nil {
+		if errors.Is(err, usecases.ErrCompanyForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -60,24 +71,131 @@ func (h *VacancyHandler) GetVacancy(c *gin.Context) {
 		})
 		return
 	}
+	if err := h.markSaved(c, vacancy); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": vacancy,
 	})
 }
 
-// GetAllVacancies получает все вакансии с фильтрацией
-// GET /api/vacancies?status=Активна
+// GetAllVacancies получает страницу вакансий с фильтрацией и сортировкой
+// GET /api/vacancies?status=Активна&type=Стажировка&skills=Go,SQL&sort=newest&limit=20&cursor=...
 func (h *VacancyHandler) GetAllVacancies(c *gin.Context) {
-	status := c.Query("status")
-	
-	vacancies, err := h.Service.GetAllVacancies(c.Request.Context(), status)
+	filter, err := parseVacancyFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	page := repositories.VacancyPageRequest{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+	if page.Limit, err = queryInt(c, "limit"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	if page.Offset, err = queryInt(c, "offset"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	result, err := h.Service.GetAllVacancies(c.Request.Context(), filter, page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err := h.markSaved(c, result.Items...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        result.Items,
+		"count":       len(result.Items),
+		"total":       result.Total,
+		"next_cursor": result.NextCursor,
+	})
+}
+
+// SearchVacancies выполняет полнотекстовый поиск вакансий
+// GET /api/vacancies/search?q=golang стажировка&format=Удалённо&limit=20&offset=0
+func (h *VacancyHandler) SearchVacancies(c *gin.Context) {
+	filter, err := parseVacancyFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	limit, err := queryInt(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid limit",
+		})
+		return
+	}
+	offset, err := queryInt(c, "offset")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid offset",
+		})
+		return
+	}
+
+	result, err := h.Service.SearchVacancies(c.Request.Context(), c.Query("q"), filter, limit, offset)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
 		return
 	}
+	vacancies := make([]*entities.Vacancy, len(result.Items))
+	for i, item := range result.Items {
+		vacancies[i] = item.Vacancy
+	}
+	if err := h.markSaved(c, vacancies...); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  result.Items,
+		"count": len(result.Items),
+		"total": result.Total,
+	})
+}
+
+// GetMyVacancies получает вакансии текущего работодателя
+// GET /api/employers/me/vacancies
+func (h *VacancyHandler) GetMyVacancies(c *gin.Context) {
+	user := middlewares.CurrentUser(c)
+
+	vacancies, err := h.Service.GetEmployerVacancies(c.Request.Context(), user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"data": vacancies,
@@ -101,7 +219,14 @@ func (h *VacancyHandler) UpdateVacancy(c *gin.Context) {
 
 	req.ID = id
 
-	if err := h.Service.UpdateVacancy(c.Request.Context(), &req); err != nil {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.UpdateVacancy(c.Request.Context(), user.ID, &req); err != nil {
+		if errors.Is(err, usecases.ErrNotVacancyOwner) || errors.Is(err, usecases.ErrCompanyForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		if err.Error() == "vacancy not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": err.Error(),
@@ -137,7 +262,14 @@ func (h *VacancyHandler) UpdateVacancyStatus(c *gin.Context) {
 		return
 	}
 
-	if err := h.Service.UpdateVacancyStatus(c.Request.Context(), id, req.Status); err != nil {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.UpdateVacancyStatus(c.Request.Context(), user.ID, id, req.Status); err != nil {
+		if errors.Is(err, usecases.ErrNotVacancyOwner) || errors.Is(err, usecases.ErrCompanyForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		if err.Error() == "vacancy not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": err.Error(),
@@ -161,7 +293,14 @@ func (h *VacancyHandler) UpdateVacancyStatus(c *gin.Context) {
 func (h *VacancyHandler) DeleteVacancy(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.Service.DeleteVacancy(c.Request.Context(), id); err != nil {
+	user := middlewares.CurrentUser(c)
+	if err := h.Service.DeleteVacancy(c.Request.Context(), user.ID, id); err != nil {
+		if errors.Is(err, usecases.ErrNotVacancyOwner) || errors.Is(err, usecases.ErrCompanyForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		if err.Error() == "vacancy not found" {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error": err.Error(),
@@ -178,3 +317,12 @@ func (h *VacancyHandler) DeleteVacancy(c *gin.Context) {
 		"message": "vacancy deleted successfully",
 	})
 }
+
+// markSaved проставляет флаг is_saved, если запрос сделан студентом
+func (h *VacancyHandler) markSaved(c *gin.Context, vacancies ...*entities.Vacancy) error {
+	user := middlewares.CurrentUser(c)
+	if user == nil || user.Role != entities.UserRoleStudent {
+		return nil
+	}
+	return h.Bookmarks.MarkSaved(c.Request.Context(), user.ID, vacancies...)
+}
@@ -0,0 +1,126 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
+	"github.com/albkvv/student-job-finder-back/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// userContextKey ключ, под которым аутентифицированный пользователь хранится в gin.Context
+const userContextKey = "currentUser"
+
+// RequireAuth проверяет bearer-токен, загружает пользователя и кладёт его в контекст запроса.
+// Запросы без валидного токена отклоняются с 401.
+func RequireAuth(userRepo repositories.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "authorization token is required",
+			})
+			return
+		}
+
+		user, err := authenticate(c, userRepo, token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.Set(userContextKey, user)
+		c.Next()
+	}
+}
+
+// RequireStreamAuth работает как RequireAuth, но дополнительно принимает токен из query-параметра access_token:
+// браузерный EventSource не умеет передавать заголовки.
+func RequireStreamAuth(userRepo repositories.UserRepository) gin.HandlerFunc {
+	requireAuth := RequireAuth(userRepo)
+	return func(c *gin.Context) {
+		if bearerToken(c) == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		requireAuth(c)
+	}
+}
+
+// OptionalAuth загружает пользователя, если передан валидный токен, но пропускает анонимные запросы.
+func OptionalAuth(userRepo repositories.UserRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := bearerToken(c); token != "" {
+			if user, err := authenticate(c, userRepo, token); err == nil {
+				c.Set(userContextKey, user)
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireRole пропускает только пользователей с одной из указанных ролей.
+// Должен использоваться после RequireAuth.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "authentication required",
+			})
+			return
+		}
+
+		for _, role := range roles {
+			if user.Role == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "insufficient permissions",
+		})
+	}
+}
+
+// CurrentUser возвращает аутентифицированного пользователя или nil для анонимного запроса
+func CurrentUser(c *gin.Context) *entities.User {
+	value, ok := c.Get(userContextKey)
+	if !ok {
+		return nil
+	}
+	user, _ := value.(*entities.User)
+	return user
+}
+
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		return ""
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+func authenticate(c *gin.Context, userRepo repositories.UserRepository, token string) (*entities.User, error) {
+	userID, err := utils.ValidateJWT(token)
+	if err != nil {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	user, err := userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil || user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
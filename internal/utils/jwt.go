@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,18 +15,26 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const (
+	// AccessTokenTTL время жизни access-токена
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL время жизни refresh-токена
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type Claims struct {
 	UserID string `json:"user_id"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJWT(userID string) (string, error) {
+// GenerateJWT выпускает access-токен и возвращает время его истечения
+func GenerateJWT(userID string) (string, time.Time, error) {
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		jwtSecret = "your-secret-key-change-me"
 	}
 
-	expirationTime := time.Now().Add(24 * time.Hour)
+	expirationTime := time.Now().Add(AccessTokenTTL)
 	claims := &Claims{
 		UserID: userID,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -34,10 +46,46 @@ func GenerateJWT(userID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(jwtSecret))
 	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expirationTime, nil
+}
+
+// GenerateRefreshToken создает случайный непрозрачный refresh-токен
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashCode возвращает HMAC одноразового кода, привязанный к идентификатору.
+// Ключ берется из CODE_HASH_SECRET или JWT_SECRET, поэтому короткий код нельзя подобрать по утекшему хешу.
+func HashCode(identifier, code string) string {
+	secret := os.Getenv("CODE_HASH_SECRET")
+	if secret == "" {
+		secret = os.Getenv("JWT_SECRET")
+	}
+	if secret == "" {
+		secret = "your-secret-key-change-me"
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(identifier + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
 
-	return tokenString, nil
+// CheckCode сравнивает код с сохраненным хешем за постоянное время
+func CheckCode(identifier, code, codeHash string) bool {
+	return hmac.Equal([]byte(HashCode(identifier, code)), []byte(codeHash))
+}
+
+// HashToken возвращает SHA-256 хеш токена для хранения на сервере
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func ValidateJWT(tokenString string) (string, error) {
@@ -71,10 +119,6 @@ func GenerateNumericCode(n int) string {
 	return fmt.Sprintf("%0*d", n, randNum.Int64())
 }
 
-func LogSMSToConsole(phone, code string) {
-	fmt.Printf("SMS-код для номера %s: %s\n", phone, code)
-}
-
 func GenerateID() string {
 	randNum, _ := rand.Int(rand.Reader, big.NewInt(999999999999999999))
 	return fmt.Sprintf("%d", randNum.Int64())
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// russianEndings распространенные окончания и суффиксы русских слов, от длинных к коротким.
+// Используется упрощенный стемминг: достаточно, чтобы "разработчик", "разработчика"
+// и "разработчиков" сводились к одной основе.
+var russianEndings = []string{
+	"ированием", "ирования", "ирование",
+	"остями", "ениями", "ениях", "ением",
+	"иями", "ость", "ости", "ения", "ение", "ений", "ями", "ами",
+	"ого", "его", "ому", "ему", "ыми", "ими", "ией", "иях", "иям", "ием",
+	"ая", "яя", "ое", "ее", "ые", "ие", "ый", "ий", "ой", "ую", "юю",
+	"ов", "ев", "ей", "ам", "ям", "ах", "ях", "ом", "ем", "ию", "ия",
+	"ых", "их", "ым", "им",
+	"ть", "ти", "ет", "ют", "ит", "ат", "ят",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь", "й",
+}
+
+// minStemLength минимальная длина основы в рунах, короче которой окончание не отбрасывается
+const minStemLength = 3
+
+// Tokenize разбивает текст на слова в нижнем регистре, заменяя "ё" на "е".
+// Символы "+" и "#" сохраняются, чтобы не терять навыки вроде "C++" и "C#".
+func Tokenize(text string) []string {
+	text = strings.ReplaceAll(strings.ToLower(text), "ё", "е")
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+	})
+}
+
+// Stem приводит слово к упрощенной основе для поиска
+func Stem(word string) string {
+	if !isCyrillic(word) {
+		return word
+	}
+	for _, ending := range russianEndings {
+		if strings.HasSuffix(word, ending) && utf8.RuneCountInString(word)-utf8.RuneCountInString(ending) >= minStemLength {
+			return strings.TrimSuffix(word, ending)
+		}
+	}
+	return word
+}
+
+// StemTokens разбивает текст на слова и приводит каждое к основе
+func StemTokens(text string) []string {
+	tokens := Tokenize(text)
+	for i, token := range tokens {
+		tokens[i] = Stem(token)
+	}
+	return tokens
+}
+
+func isCyrillic(word string) bool {
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return true
+		}
+	}
+	return false
+}
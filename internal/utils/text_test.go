@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Go-разработчик", []string{"go", "разработчик"}},
+		{"C++, C# и Java!", []string{"c++", "c#", "и", "java"}},
+		{"Ёжик в тумане", []string{"ежик", "в", "тумане"}},
+		{"  ", []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := Tokenize(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"разработчик", "разработчик"},
+		{"разработчика", "разработчик"},
+		{"разработчиков", "разработчик"},
+		{"программирование", "программ"},
+		{"программирования", "программ"},
+		{"опытом", "опыт"},
+		// Основа не короче minStemLength
+		{"мая", "мая"},
+		{"бег", "бег"},
+		// Латиница не изменяется
+		{"developers", "developers"},
+		{"c++", "c++"},
+	}
+
+	for _, tt := range tests {
+		if got := Stem(tt.word); got != tt.want {
+			t.Errorf("Stem(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestStemTokensMatchesWordForms(t *testing.T) {
+	forms := []string{"Разработчик", "разработчика", "РАЗРАБОТЧИКОВ"}
+	for _, form := range forms {
+		got := StemTokens(form)
+		if len(got) != 1 || got[0] != "разработчик" {
+			t.Errorf("StemTokens(%q) = %q, want [разработчик]", form, got)
+		}
+	}
+}
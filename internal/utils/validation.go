@@ -2,6 +2,7 @@ package utils
 
 import (
 	"errors"
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -52,3 +53,12 @@ func ValidateRole(role string) error {
 	}
 	return nil
 }
+
+// ValidateHTTPURL проверяет, что строка — абсолютная http(s)-ссылка
+func ValidateHTTPURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New("must be an http or https URL")
+	}
+	return nil
+}
@@ -2,20 +2,28 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/albkvv/student-job-finder-back/internal/application/usecases"
 	"github.com/albkvv/student-job-finder-back/internal/db"
+	"github.com/albkvv/student-job-finder-back/internal/domain/entities"
+	"github.com/albkvv/student-job-finder-back/internal/domain/matching"
+	"github.com/albkvv/student-job-finder-back/internal/domain/repositories"
 	"github.com/albkvv/student-job-finder-back/internal/infrastructure/inmemory"
 	"github.com/albkvv/student-job-finder-back/internal/infrastructure/mongo"
+	"github.com/albkvv/student-job-finder-back/internal/infrastructure/notify"
 	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/handlers"
+	"github.com/albkvv/student-job-finder-back/internal/interfaces/http/middlewares"
 )
 
 func main() {
@@ -32,11 +40,17 @@ func main() {
 
 	r := gin.Default()
 
+	// По умолчанию gin доверяет X-Forwarded-For от любого клиента, и лимиты по IP легко обойти.
+	// Адрес клиента берется из заголовков только за прокси из TRUSTED_PROXIES.
+	if err := r.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -56,16 +70,138 @@ func main() {
 	
 	// User repository and service
 	usersColl := client.Database(dbName).Collection("users")
+	if migrated, err := mongo.MigrateUserDocuments(ctx, usersColl); err != nil {
+		log.Printf("failed to migrate user documents: %v", err)
+	} else if migrated > 0 {
+		log.Printf("migrated %d user documents to the current schema", migrated)
+	}
+	if err := mongo.EnsureUserIndexes(ctx, usersColl); err != nil {
+		log.Printf("failed to create user indexes: %v", err)
+	}
 	userRepo := mongo.NewMongoUserRepo(usersColl)
-	codeRepo := inmemory.NewInMemoryCodeRepo()
-	authService := usecases.NewAuthService(userRepo, codeRepo)
+	codeRepo, err := newCodeRepo(ctx, client.Database(dbName).Collection("verification_codes"))
+	if err != nil {
+		log.Fatalf("verification code store error: %v", err)
+	}
+	refreshTokensColl := client.Database(dbName).Collection("refresh_tokens")
+	if err := mongo.EnsureRefreshTokenIndexes(ctx, refreshTokensColl); err != nil {
+		log.Printf("failed to create refresh token indexes: %v", err)
+	}
+	refreshTokenRepo := mongo.NewMongoRefreshTokenRepo(refreshTokensColl)
+	smsSender, emailSender, err := notify.NewSendersFromEnv()
+	if err != nil {
+		log.Fatalf("notification sender config error: %v", err)
+	}
+	rateLimitRepo, err := newRateLimitRepo(ctx, client.Database(dbName).Collection("rate_limits"))
+	if err != nil {
+		log.Fatalf("rate limit store error: %v", err)
+	}
+	otpLimiter := usecases.NewOTPLimiter(rateLimitRepo, usecases.DefaultOTPLimitConfig())
+	authService := usecases.NewAuthService(userRepo, codeRepo, refreshTokenRepo, smsSender, emailSender, otpLimiter)
 	authHandler := handlers.NewAuthHandler(authService)
+	profileHandler := handlers.NewProfileHandler(authService)
+
+	userCleaner := usecases.NewUnverifiedUserCleaner(userRepo,
+		durationFromEnv("UNVERIFIED_USER_MAX_AGE", 24*time.Hour),
+		durationFromEnv("UNVERIFIED_USER_CLEANUP_INTERVAL", time.Hour))
+	go userCleaner.Run(context.Background())
 	
 	// Vacancy repository and service
 	vacanciesColl := client.Database(dbName).Collection("vacancies")
+	if err := mongo.EnsureVacancyIndexes(ctx, vacanciesColl); err != nil {
+		log.Printf("failed to create vacancy indexes: %v", err)
+	}
 	vacancyRepo := mongo.NewMongoVacancyRepo(vacanciesColl)
-	vacancyService := usecases.NewVacancyService(vacancyRepo)
-	vacancyHandler := handlers.NewVacancyHandler(vacancyService)
+	vacancySearcher := mongo.NewMongoVacancySearcher(vacanciesColl)
+
+	// Company repository and service
+	companiesColl := client.Database(dbName).Collection("companies")
+	if err := mongo.EnsureCompanyIndexes(ctx, companiesColl); err != nil {
+		log.Printf("failed to create company indexes: %v", err)
+	}
+	companyRepo := mongo.NewMongoCompanyRepo(companiesColl)
+	companyService := usecases.NewCompanyService(companyRepo, vacancyRepo, codeRepo, smsSender, emailSender, otpLimiter.Scoped("invite"))
+	companyHandler := handlers.NewCompanyHandler(companyService)
+
+	// Bookmark repository and service
+	bookmarksColl := client.Database(dbName).Collection("bookmarks")
+	if err := mongo.EnsureBookmarkIndexes(ctx, bookmarksColl); err != nil {
+		log.Printf("failed to create bookmark indexes: %v", err)
+	}
+	bookmarkRepo := mongo.NewMongoBookmarkRepo(bookmarksColl)
+	bookmarkService := usecases.NewBookmarkService(bookmarkRepo, vacancyRepo, companyRepo)
+	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkService)
+
+	// Notification repository: внутренний почтовый ящик пользователей
+	notificationsColl := client.Database(dbName).Collection("notifications")
+	if err := mongo.EnsureNotificationIndexes(ctx, notificationsColl); err != nil {
+		log.Printf("failed to create notification indexes: %v", err)
+	}
+	notificationRepo := mongo.NewMongoNotificationRepo(notificationsColl)
+	// Все события приложения публикуются в ящик через один сервис уведомлений
+	// и рассылаются подключенным клиентам брокером событий (в памяти процесса, один узел)
+	eventBroker := inmemory.NewInMemoryEventBroker()
+	notificationService := usecases.NewNotificationService(notificationRepo, eventBroker)
+	notificationHandler := handlers.NewNotificationHandler(notificationService, durationFromEnv("NOTIFICATION_HEARTBEAT_INTERVAL", 25*time.Second))
+
+	// Saved search repository and service: оповещения приходят во внутренний ящик и на email
+	savedSearchesColl := client.Database(dbName).Collection("saved_searches")
+	if err := mongo.EnsureSavedSearchIndexes(ctx, savedSearchesColl); err != nil {
+		log.Printf("failed to create saved search indexes: %v", err)
+	}
+	savedSearchRepo := mongo.NewMongoSavedSearchRepo(savedSearchesColl)
+	alertNotifier := notify.NewAlertNotifiers(
+		notify.NewInboxAlertNotifier(notificationService),
+		notify.NewEmailAlertNotifier(userRepo, emailSender),
+	)
+	savedSearchService := usecases.NewSavedSearchService(savedSearchRepo, vacancyRepo, companyRepo, alertNotifier)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+	go savedSearchService.RunDigests(context.Background(), durationFromEnv("ALERT_DIGEST_INTERVAL", 15*time.Minute))
+
+	// Application repository and service
+	applicationsColl := client.Database(dbName).Collection("applications")
+	if err := mongo.EnsureApplicationIndexes(ctx, applicationsColl); err != nil {
+		log.Printf("failed to create application indexes: %v", err)
+	}
+	applicationRepo := mongo.NewMongoApplicationRepo(applicationsColl)
+	applicationService := usecases.NewApplicationService(applicationRepo, vacancyRepo, companyRepo, notificationService)
+	applicationHandler := handlers.NewApplicationHandler(applicationService)
+
+	vacancyService := usecases.NewVacancyService(vacancyRepo, vacancySearcher, companyRepo, applicationRepo, bookmarkRepo, savedSearchService, notificationService)
+	vacancyHandler := handlers.NewVacancyHandler(vacancyService, bookmarkService)
+
+
+	// Student profile repository and service
+	studentProfilesColl := client.Database(dbName).Collection("student_profiles")
+	if err := mongo.EnsureStudentProfileIndexes(ctx, studentProfilesColl); err != nil {
+		log.Printf("failed to create student profile indexes: %v", err)
+	}
+	studentProfileRepo := mongo.NewMongoStudentProfileRepo(studentProfilesColl)
+	studentProfileService := usecases.NewStudentProfileService(studentProfileRepo, applicationRepo, vacancyRepo, companyRepo)
+	studentProfileHandler := handlers.NewStudentProfileHandler(studentProfileService)
+
+	// Conversation repositories and service: сообщения доставляются участникам через брокер событий
+	conversationsColl := client.Database(dbName).Collection("conversations")
+	if err := mongo.EnsureConversationIndexes(ctx, conversationsColl); err != nil {
+		log.Printf("failed to create conversation indexes: %v", err)
+	}
+	messagesColl := client.Database(dbName).Collection("messages")
+	if err := mongo.EnsureMessageIndexes(ctx, messagesColl); err != nil {
+		log.Printf("failed to create message indexes: %v", err)
+	}
+	conversationRepo := mongo.NewMongoConversationRepo(conversationsColl)
+	messageRepo := mongo.NewMongoMessageRepo(messagesColl)
+	conversationService := usecases.NewConversationService(conversationRepo, messageRepo, vacancyRepo, applicationRepo, studentProfileRepo, companyRepo, eventBroker)
+	conversationHandler := handlers.NewConversationHandler(conversationService)
+
+	// Recommendation service: оценка вакансий не зависит от хранилища, см. пакет matching
+	recommendationService := usecases.NewRecommendationService(studentProfileRepo, vacancyRepo, applicationRepo, companyRepo, matching.NewScorer())
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+
+	requireAuth := middlewares.RequireAuth(userRepo)
+	optionalAuth := middlewares.OptionalAuth(userRepo)
+	employerOnly := middlewares.RequireRole(entities.UserRoleEmployer)
+	studentOnly := middlewares.RequireRole(entities.UserRoleStudent)
 
 	api := r.Group("/api")
 	{
@@ -79,13 +215,86 @@ func main() {
 		api.POST("/request-code", authHandler.RequestCode)
 		api.POST("/verify-code", authHandler.VerifyCode)
 		
-		// Vacancy routes
-		api.POST("/vacancies", vacancyHandler.CreateVacancy)
-		api.GET("/vacancies", vacancyHandler.GetAllVacancies)
-		api.GET("/vacancies/:id", vacancyHandler.GetVacancy)
-		api.PUT("/vacancies/:id", vacancyHandler.UpdateVacancy)
-		api.PATCH("/vacancies/:id/status", vacancyHandler.UpdateVacancyStatus)
-		api.DELETE("/vacancies/:id", vacancyHandler.DeleteVacancy)
+		// Vacancy routes: чтение открыто всем, изменение — только работодателям.
+		// Для студента в ответах чтения приходит флаг is_saved.
+		api.GET("/vacancies", optionalAuth, vacancyHandler.GetAllVacancies)
+		api.GET("/vacancies/search", optionalAuth, vacancyHandler.SearchVacancies)
+		api.GET("/vacancies/:id", optionalAuth, vacancyHandler.GetVacancy)
+		api.POST("/vacancies", requireAuth, employerOnly, vacancyHandler.CreateVacancy)
+		api.PUT("/vacancies/:id", requireAuth, employerOnly, vacancyHandler.UpdateVacancy)
+		api.PATCH("/vacancies/:id/status", requireAuth, employerOnly, vacancyHandler.UpdateVacancyStatus)
+		api.DELETE("/vacancies/:id", requireAuth, employerOnly, vacancyHandler.DeleteVacancy)
+		api.GET("/employers/me/vacancies", requireAuth, employerOnly, vacancyHandler.GetMyVacancies)
+
+		// Application routes
+		api.POST("/vacancies/:id/applications", requireAuth, studentOnly, applicationHandler.Apply)
+		api.GET("/vacancies/:id/applications", requireAuth, employerOnly, applicationHandler.GetVacancyApplications)
+		api.GET("/students/me/applications", requireAuth, studentOnly, applicationHandler.GetMyApplications)
+		api.PATCH("/applications/:id/status", requireAuth, employerOnly, applicationHandler.ChangeStatus)
+
+		// Bookmark routes
+		api.GET("/students/me/saved-vacancies", requireAuth, studentOnly, bookmarkHandler.GetSavedVacancies)
+		api.POST("/students/me/saved-vacancies", requireAuth, studentOnly, bookmarkHandler.SaveVacancy)
+		api.DELETE("/students/me/saved-vacancies/:vacancyId", requireAuth, studentOnly, bookmarkHandler.RemoveVacancy)
+
+		// Saved search routes
+		api.GET("/students/me/saved-searches", requireAuth, studentOnly, savedSearchHandler.GetSearches)
+		api.POST("/students/me/saved-searches", requireAuth, studentOnly, savedSearchHandler.CreateSearch)
+		api.GET("/students/me/saved-searches/:id", requireAuth, studentOnly, savedSearchHandler.GetSearch)
+		api.PUT("/students/me/saved-searches/:id", requireAuth, studentOnly, savedSearchHandler.UpdateSearch)
+		api.DELETE("/students/me/saved-searches/:id", requireAuth, studentOnly, savedSearchHandler.DeleteSearch)
+
+		// Company routes: карточка компании открыта всем, управление — команде согласно ролям
+		api.GET("/companies/:id", companyHandler.GetCompany)
+		api.POST("/companies", requireAuth, employerOnly, companyHandler.CreateCompany)
+		api.PUT("/companies/:id", requireAuth, employerOnly, companyHandler.UpdateCompany)
+		api.DELETE("/companies/:id", requireAuth, employerOnly, companyHandler.DeleteCompany)
+		api.POST("/companies/:id/invitations", requireAuth, employerOnly, companyHandler.InviteMember)
+		api.DELETE("/companies/:id/invitations", requireAuth, employerOnly, companyHandler.RevokeInvitation)
+		api.POST("/companies/:id/invitations/accept", requireAuth, employerOnly, companyHandler.AcceptInvitation)
+		api.PATCH("/companies/:id/members/:userId", requireAuth, employerOnly, companyHandler.ChangeMemberRole)
+		api.DELETE("/companies/:id/members/:userId", requireAuth, employerOnly, companyHandler.RemoveMember)
+		api.GET("/employers/me/companies", requireAuth, employerOnly, companyHandler.GetMyCompanies)
+
+		// Student profile routes
+		api.GET("/students/me/profile", requireAuth, studentOnly, studentProfileHandler.GetMyProfile)
+		api.POST("/students/me/profile", requireAuth, studentOnly, studentProfileHandler.CreateMyProfile)
+		api.PUT("/students/me/profile", requireAuth, studentOnly, studentProfileHandler.UpdateMyProfile)
+		api.DELETE("/students/me/profile", requireAuth, studentOnly, studentProfileHandler.DeleteMyProfile)
+		api.GET("/students/:id/profile", requireAuth, employerOnly, studentProfileHandler.GetStudentProfile)
+
+		// Recommendation routes
+		api.GET("/recommendations", requireAuth, studentOnly, recommendationHandler.GetRecommendations)
+		api.GET("/vacancies/:id/candidates", requireAuth, employerOnly, recommendationHandler.GetVacancyCandidates)
+
+		// Profile routes
+		api.GET("/me", requireAuth, profileHandler.GetMe)
+		api.PATCH("/me", requireAuth, profileHandler.UpdateMe)
+		api.POST("/me/change-password", requireAuth, profileHandler.ChangePassword)
+		api.POST("/me/change-password/code", requireAuth, profileHandler.RequestPasswordSetCode)
+		api.POST("/me/change-email", requireAuth, profileHandler.RequestEmailChange)
+		api.POST("/me/change-email/confirm", requireAuth, profileHandler.ConfirmEmailChange)
+		api.POST("/me/change-phone", requireAuth, profileHandler.RequestPhoneChange)
+		api.POST("/me/change-phone/confirm", requireAuth, profileHandler.ConfirmPhoneChange)
+
+		// Conversation routes: переписку видят студент и сторона работодателя вакансии
+		api.POST("/conversations", requireAuth, conversationHandler.StartConversation)
+		api.GET("/conversations", requireAuth, conversationHandler.GetConversations)
+		api.GET("/conversations/unread-count", requireAuth, conversationHandler.GetUnreadCount)
+		api.GET("/conversations/:id", requireAuth, conversationHandler.GetConversation)
+		api.POST("/conversations/:id/read", requireAuth, conversationHandler.MarkRead)
+		api.GET("/conversations/:id/messages", requireAuth, conversationHandler.GetMessages)
+		api.POST("/conversations/:id/messages", requireAuth, conversationHandler.SendMessage)
+		api.PATCH("/conversations/:id/messages/:messageId", requireAuth, conversationHandler.EditMessage)
+		api.DELETE("/conversations/:id/messages/:messageId", requireAuth, conversationHandler.DeleteMessage)
+
+		// Notification routes: почтовый ящик доступен любой роли
+		api.GET("/notifications", requireAuth, notificationHandler.GetNotifications)
+		api.GET("/notifications/stream", middlewares.RequireStreamAuth(userRepo), notificationHandler.Stream)
+		api.GET("/notifications/unread-count", requireAuth, notificationHandler.GetUnreadCount)
+		api.POST("/notifications/read-all", requireAuth, notificationHandler.MarkAllRead)
+		api.PATCH("/notifications/:id/read", requireAuth, notificationHandler.MarkRead)
+		api.DELETE("/notifications/:id", requireAuth, notificationHandler.DeleteNotification)
 	}
 
 	authGroup := r.Group("/auth")
@@ -96,6 +305,10 @@ func main() {
 		authGroup.POST("/verify-email-code", authHandler.VerifyEmailCode)
 		authGroup.POST("/request-phone-code", authHandler.RequestCode)
 		authGroup.POST("/verify-phone-code", authHandler.VerifyCode)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", authHandler.Logout)
+		authGroup.POST("/forgot-password", authHandler.ForgotPassword)
+		authGroup.POST("/reset-password", authHandler.ResetPassword)
 	}
 
 	port := os.Getenv("PORT")
@@ -106,3 +319,59 @@ func main() {
 		log.Fatalf("server error: %v", err)
 	}
 }
+
+// newCodeRepo выбирает хранилище кодов подтверждения по CODE_STORE: memory (по умолчанию) или mongo.
+// Хранилище в памяти теряет коды при перезапуске и не разделяется между репликами.
+func newCodeRepo(ctx context.Context, coll *mongodriver.Collection) (repositories.VerificationCodeRepository, error) {
+	switch store := os.Getenv("CODE_STORE"); store {
+	case "", "memory":
+		return inmemory.NewInMemoryCodeRepo(), nil
+	case "mongo":
+		if err := mongo.EnsureCodeIndexes(ctx, coll); err != nil {
+			log.Printf("failed to create verification code indexes: %v", err)
+		}
+		return mongo.NewMongoCodeRepo(coll), nil
+	default:
+		return nil, fmt.Errorf("unknown CODE_STORE %q", store)
+	}
+}
+
+// trustedProxies читает TRUSTED_PROXIES: IP и подсети через запятую; пусто — не доверять никому
+func trustedProxies() []string {
+	var proxies []string
+	for _, part := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			proxies = append(proxies, part)
+		}
+	}
+	return proxies
+}
+
+// newRateLimitRepo выбирает хранилище счетчиков ограничения частоты по RATE_LIMIT_STORE: memory (по умолчанию) или mongo
+func newRateLimitRepo(ctx context.Context, coll *mongodriver.Collection) (repositories.RateLimitRepository, error) {
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		return inmemory.NewInMemoryRateLimitRepo(), nil
+	case "mongo":
+		if err := mongo.EnsureRateLimitIndexes(ctx, coll); err != nil {
+			log.Printf("failed to create rate limit indexes: %v", err)
+		}
+		return mongo.NewMongoRateLimitRepo(coll), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", store)
+	}
+}
+
+// durationFromEnv читает длительность в формате time.ParseDuration (например, "24h") или возвращает значение по умолчанию
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		log.Printf("invalid %s %q, using %s", key, raw, fallback)
+		return fallback
+	}
+	return value
+}
@@ -34,12 +34,26 @@ curl -X POST "$BASE_URL/auth/login-password" \
   }' | jq '.'
 
 echo -e "\n\n4. Login with Phone + Password"
-curl -X POST "$BASE_URL/auth/login-password" \
+LOGIN_RESPONSE=$(curl -s -X POST "$BASE_URL/auth/login-password" \
   -H "Content-Type: application/json" \
   -d '{
     "identifier": "+77001234567",
     "password": "password123"
-  }' | jq '.'
+  }')
+echo "$LOGIN_RESPONSE" | jq '.'
+REFRESH_TOKEN=$(echo "$LOGIN_RESPONSE" | jq -r '.refresh_token')
+
+echo -e "\n\n4.1. Refresh Tokens (rotates refresh token)"
+REFRESH_RESPONSE=$(curl -s -X POST "$BASE_URL/auth/refresh" \
+  -H "Content-Type: application/json" \
+  -d "{\"refresh_token\": \"$REFRESH_TOKEN\"}")
+echo "$REFRESH_RESPONSE" | jq '.'
+NEW_REFRESH_TOKEN=$(echo "$REFRESH_RESPONSE" | jq -r '.refresh_token')
+
+echo -e "\n\n4.2. Logout"
+curl -X POST "$BASE_URL/auth/logout" \
+  -H "Content-Type: application/json" \
+  -d "{\"refresh_token\": \"$NEW_REFRESH_TOKEN\"}" | jq '.'
 
 echo -e "\n\n5. Request Email OTP Code"
 curl -X POST "$BASE_URL/auth/request-email-code" \